@@ -0,0 +1,225 @@
+package version
+
+import (
+	"encoding/binary"
+	"math/big"
+	"strconv"
+)
+
+// Binary encoding markers.
+// Number lengths are offset by binaryNumber so that they sort above the pre-release and release markers.
+const (
+	binaryPreRelease   = 0x00
+	binaryRelease      = 0x01
+	binaryNumber       = 0x02
+	binaryBuild        = 0x00
+	binaryNumeric      = 0x01
+	binaryAlphanumeric = 0x02
+	binaryTerminator   = 0x00
+)
+
+// MarshalBinary encodes the version in a compact binary form whose byte order matches the order of versions described by Compare.
+// https://pkg.go.dev/encoding#BinaryMarshaler
+//
+// Each numeric component is written as a byte giving its length, followed by its value in big-endian order.
+// Trailing zero Segments are omitted, as they do not affect the order of versions.
+// These are followed by a marker for a normal version or a pre-release, the pre-release identifiers, and finally any build metadata.
+// Since build metadata is written last, it only affects the order of versions that Compare considers equal.
+// Use SortKey for an encoding without build metadata.
+//
+// The original Text of the version is not preserved, so a decoded version is formatted by SemanticString.
+func (v Version) MarshalBinary() ([]byte, error) {
+	return v.appendBinary(nil, true)
+}
+
+// UnmarshalBinary decodes a version from the binary form described by MarshalBinary.
+// https://pkg.go.dev/encoding#BinaryUnmarshaler
+func (v *Version) UnmarshalBinary(data []byte) error {
+	rest := data
+	numbers := []Number{}
+
+	for len(numbers) < 3 || len(rest) > 0 && rest[0] >= binaryNumber {
+		digits, r, ok := readBinaryNumber(rest)
+		if !ok {
+			return newError(ErrInvalidBinary, string(data))
+		}
+		numbers = append(numbers, Number(digits))
+		rest = r
+	}
+
+	decoded := newVersion(numbers)
+	if len(rest) == 0 {
+		return newError(ErrInvalidBinary, string(data))
+	}
+
+	switch rest[0] {
+	case binaryRelease:
+		rest = rest[1:]
+	case binaryPreRelease:
+		rest = rest[1:]
+		for len(rest) > 0 && rest[0] != binaryBuild {
+			switch rest[0] {
+			case binaryNumeric:
+				digits, r, ok := readBinaryNumber(rest[1:])
+				if !ok {
+					return newError(ErrInvalidBinary, string(data))
+				}
+				decoded.PreRelease = append(decoded.PreRelease, Identifier(digits))
+				rest = r
+			case binaryAlphanumeric:
+				end := 1
+				for end < len(rest) && rest[end] != binaryTerminator {
+					end++
+				}
+				if end == 1 || end == len(rest) {
+					return newError(ErrInvalidBinary, string(data))
+				}
+				decoded.PreRelease = append(decoded.PreRelease, Identifier(rest[1:end]))
+				rest = rest[end+1:]
+			default:
+				return newError(ErrInvalidBinary, string(data))
+			}
+		}
+		if len(decoded.PreRelease) == 0 {
+			return newError(ErrInvalidBinary, string(data))
+		}
+	default:
+		return newError(ErrInvalidBinary, string(data))
+	}
+
+	if len(rest) > 0 {
+		if len(rest) == 1 {
+			return newError(ErrInvalidBinary, string(data))
+		}
+		decoded.Build = splitIdentifiers(string(rest[1:]))
+	}
+
+	decoded.Extension = formatExtension(decoded.PreRelease, decoded.Build)
+	*v = *decoded
+	return nil
+}
+
+// MarshalBinary encodes the module version in the binary form described by Version.MarshalBinary.
+// https://pkg.go.dev/encoding#BinaryMarshaler
+func (m ModuleVersion) MarshalBinary() ([]byte, error) {
+	if m.Version == nil {
+		return nil, nil
+	}
+	return m.Version.MarshalBinary()
+}
+
+// UnmarshalBinary decodes a module version from the binary form described by Version.MarshalBinary, and checks it using ParseModule.
+// https://pkg.go.dev/encoding#BinaryUnmarshaler
+func (m *ModuleVersion) UnmarshalBinary(data []byte) error {
+	v := &Version{}
+	if err := v.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	return m.UnmarshalText([]byte("v" + v.SemanticString()))
+}
+
+// SortKey returns the version in the binary form described by MarshalBinary, without build metadata.
+// Comparing the sort keys of two versions with bytes.Compare gives the same result as Compare.
+//
+// If the version cannot be encoded, such as a version with a negative number or a number longer than 253 bytes, nil is returned.
+func (v *Version) SortKey() []byte {
+	if v == nil {
+		return nil
+	}
+
+	key, err := v.appendBinary(nil, false)
+	if err != nil {
+		return nil
+	}
+	return key
+}
+
+// appendBinary appends the binary form of the version to a byte slice, optionally including build metadata.
+func (v *Version) appendBinary(b []byte, withBuild bool) ([]byte, error) {
+	var ok bool
+	for _, n := range v.sortNumbers() {
+		if b, ok = appendBinaryNumber(b, string(n)); !ok {
+			return nil, notSortable(v, string(n))
+		}
+	}
+
+	preRelease, build := v.identifiers()
+	if len(preRelease) == 0 {
+		b = append(b, binaryRelease)
+	} else {
+		b = append(b, binaryPreRelease)
+		for _, id := range preRelease {
+			if id.Numeric() {
+				b = append(b, binaryNumeric)
+				if b, ok = appendBinaryNumber(b, string(id)); !ok {
+					return nil, notSortable(v, string(id))
+				}
+			} else {
+				for i := 0; i < len(id); i++ {
+					if id[i] == binaryTerminator {
+						return nil, newError(ErrNotSortable, v.String())
+					}
+				}
+				b = append(b, binaryAlphanumeric)
+				b = append(b, id...)
+				b = append(b, binaryTerminator)
+			}
+		}
+	}
+
+	if withBuild && len(build) > 0 {
+		b = append(b, binaryBuild)
+		b = append(b, joinIdentifiers(build)...)
+	}
+
+	return b, nil
+}
+
+// appendBinaryNumber appends a string of decimal digits to a byte slice in binary form.
+func appendBinaryNumber(b []byte, digits string) ([]byte, bool) {
+	if !isNumeric(digits) {
+		return b, false
+	}
+
+	var value []byte
+	if len(digits) < 20 {
+		n, _ := strconv.ParseUint(digits, 10, 64)
+		buf := binary.BigEndian.AppendUint64(nil, n)
+		i := 0
+		for i < len(buf) && buf[i] == 0 {
+			i++
+		}
+		value = buf[i:]
+	} else {
+		n, _ := new(big.Int).SetString(digits, 10)
+		value = n.Bytes()
+	}
+
+	if len(value) > 0xff-binaryNumber {
+		return b, false
+	}
+	b = append(b, byte(len(value)+binaryNumber))
+	return append(b, value...), true
+}
+
+// readBinaryNumber reads a number in binary form from the start of a byte slice, returning its decimal digits and the remainder of the slice.
+func readBinaryNumber(data []byte) (string, []byte, bool) {
+	if len(data) == 0 || data[0] < binaryNumber {
+		return "", nil, false
+	}
+
+	n := int(data[0] - binaryNumber)
+	if len(data) < n+1 || n > 0 && data[1] == 0 {
+		return "", nil, false
+	}
+
+	value := data[1 : n+1]
+	if n <= 8 {
+		u := uint64(0)
+		for _, c := range value {
+			u = u<<8 | uint64(c)
+		}
+		return strconv.FormatUint(u, 10), data[n+1:], true
+	}
+	return new(big.Int).SetBytes(value).String(), data[n+1:], true
+}
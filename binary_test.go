@@ -0,0 +1,151 @@
+package version
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func TestVersion_MarshalBinary(t *testing.T) {
+	type TestCase struct {
+		Input    *Version
+		Expected []byte
+		Decoded  string
+	}
+
+	testCases := []TestCase{
+		{Input: MustParse("0.0.0"), Expected: []byte{2, 2, 2, 1}, Decoded: "0.0.0"},
+		{Input: MustParse("v1.2.3"), Expected: []byte{3, 1, 3, 2, 3, 3, 1}, Decoded: "1.2.3"},
+		{Input: MustParse("1.256.0"), Expected: []byte{3, 1, 4, 1, 0, 2, 1}, Decoded: "1.256.0"},
+		{Input: MustParse("1.0.0-rc.1"), Expected: []byte{3, 1, 2, 2, 0, 2, 'r', 'c', 0, 1, 3, 1}, Decoded: "1.0.0-rc.1"},
+		{Input: MustParse("1.0.0+b.5"), Expected: []byte{3, 1, 2, 2, 1, 0, 'b', '.', '5'}, Decoded: "1.0.0+b.5"},
+		{Input: MustParse("1.0.0-0+b"), Expected: []byte{3, 1, 2, 2, 0, 1, 2, 0, 'b'}, Decoded: "1.0.0-0+b"},
+		{Input: MustParse("1.2.3.4.0"), Expected: []byte{3, 1, 3, 2, 3, 3, 3, 4, 1}, Decoded: "1.2.3.4"},
+		{Input: MustParse("1.0.0-99999999999999999999"), Expected: []byte{3, 1, 2, 2, 0, 1, 11, 0x05, 0x6b, 0xc7, 0x5e, 0x2d, 0x63, 0x0f, 0xff, 0xff}, Decoded: "1.0.0-99999999999999999999"},
+	}
+
+	for i, testCase := range testCases {
+		actual, err := testCase.Input.MarshalBinary()
+		if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+			continue
+		}
+		if !bytes.Equal(actual, testCase.Expected) {
+			t.Errorf("test %d failed (expected %v, actual %v)", i, testCase.Expected, actual)
+			continue
+		}
+
+		decoded := &Version{}
+		if err := decoded.UnmarshalBinary(actual); err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+		} else if decoded.String() != testCase.Decoded {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, testCase.Decoded, decoded)
+		} else {
+			t.Logf("test %d passed with %v", i, actual)
+		}
+	}
+}
+
+func TestVersion_UnmarshalBinary(t *testing.T) {
+	testCases := [][]byte{
+		nil,
+		{3, 1, 3, 2},
+		{3, 1, 3, 2, 3, 3},
+		{3, 1, 3, 2, 3, 3, 9},
+		{3, 0, 2, 2, 1},
+		{3, 1, 2, 2, 0},
+		{3, 1, 2, 2, 0, 2, 'r', 'c'},
+		{3, 1, 2, 2, 0, 2, 0},
+		{3, 1, 2, 2, 0, 3},
+		{3, 1, 2, 2, 1, 0},
+	}
+
+	for i, testCase := range testCases {
+		v := &Version{}
+		err := v.UnmarshalBinary(testCase)
+		if err == nil {
+			t.Errorf("test %d failed (expected error %s, actual %s)", i, ErrInvalidBinary, v)
+		} else if !errors.Is(err, ErrInvalidBinary) {
+			t.Errorf("test %d failed (expected error %s, actual error %s)", i, ErrInvalidBinary, err)
+		} else {
+			t.Logf("test %d passed with error %s", i, err)
+		}
+	}
+}
+
+// TestVersion_SortKey checks that the byte order of sort keys matches Compare, and that binary encoding round-trips, for randomly generated versions.
+// Numeric identifiers with leading zeros are normalized by encoding, so decoded versions are compared by precedence and build metadata only.
+func TestVersion_SortKey(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	list := List{}
+	for i := 0; i < 500; i++ {
+		list = append(list, randomVersion(r))
+	}
+
+	for _, a := range list {
+		aKey := a.SortKey()
+		if aKey == nil {
+			t.Fatalf("sort key of %s failed", a)
+		}
+
+		data, err := a.MarshalBinary()
+		if err != nil {
+			t.Fatalf("marshal of %s failed with error %s", a, err)
+		}
+		decoded := &Version{}
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Errorf("unmarshal of %s failed with error %s", a, err)
+		} else if decoded.Compare(a) != 0 || formatExtension(nil, decoded.Build) != formatExtension(nil, a.Build) {
+			t.Errorf("round trip of %s failed (actual %s)", a, decoded)
+		}
+
+		for _, b := range list {
+			expected := a.Compare(b)
+			actual := bytes.Compare(aKey, b.SortKey())
+			if expected != actual {
+				t.Errorf("order of %s and %s failed (expected %d, actual %d)", a, b, expected, actual)
+			}
+		}
+	}
+}
+
+// randomVersion generates a version with a mix of small, large and arbitrarily large numbers and pre-release identifiers that are likely to collide.
+func randomVersion(r *rand.Rand) *Version {
+	number := func() int {
+		switch r.Intn(4) {
+		case 0:
+			return 0
+		case 1:
+			return r.Intn(3)
+		case 2:
+			return r.Intn(300)
+		}
+		return r.Int()
+	}
+
+	v := &Version{Major: number(), Minor: number(), Patch: number()}
+	if r.Intn(8) == 0 {
+		v.setNumber(r.Intn(3), strconv.Itoa(r.Intn(1000))+"0000000000000000000000")
+	}
+	for n := r.Intn(4) - 1; n > 0; n-- {
+		v.Segments = append(v.Segments, Number(strconv.Itoa(number())))
+	}
+
+	alphabet := []string{"a", "b", "a-", "A", "rc", "alpha", "beta", "-", "0a", "1", "01"}
+	for n := r.Intn(4); n > 0; n-- {
+		if r.Intn(2) == 0 {
+			v.PreRelease = append(v.PreRelease, Identifier(strconv.Itoa(number())))
+		} else {
+			v.PreRelease = append(v.PreRelease, Identifier(alphabet[r.Intn(len(alphabet))]))
+		}
+	}
+	if r.Intn(4) == 0 {
+		v.Build = []Identifier{"build", Identifier(strconv.Itoa(r.Intn(10)))}
+	}
+
+	v.Extension = formatExtension(v.PreRelease, v.Build)
+	return v
+}
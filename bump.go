@@ -0,0 +1,137 @@
+package version
+
+// NextMajor returns the next major version, such as 2.0.0 for 1.2.3.
+// A pre-release of a major version, such as 2.0.0-rc.1, is promoted to its release instead.
+//
+// The returned version has no pre-release, build metadata or Text.
+func (v *Version) NextMajor() *Version {
+	if v == nil {
+		return nil
+	}
+
+	if len(v.preRelease()) > 0 && v.isZeroAfter(sectionMajor) {
+		return v.Release()
+	}
+	return v.next(sectionMajor)
+}
+
+// NextMinor returns the next minor version, such as 1.3.0 for 1.2.3.
+// A pre-release of a minor version, such as 1.3.0-rc.1, is promoted to its release instead.
+//
+// The returned version has no pre-release, build metadata or Text.
+func (v *Version) NextMinor() *Version {
+	if v == nil {
+		return nil
+	}
+
+	if len(v.preRelease()) > 0 && v.isZeroAfter(sectionMinor) {
+		return v.Release()
+	}
+	return v.next(sectionMinor)
+}
+
+// NextPatch returns the next patch version, such as 1.2.4 for 1.2.3.
+// A pre-release of a patch version, such as 1.2.4-rc.1, is promoted to its release instead.
+// A pre-release with non-zero Segments, such as 1.2.3.4-rc.1, is not a pre-release of a patch version, so 1.2.4 is returned.
+//
+// The returned version has no pre-release, build metadata or Text.
+func (v *Version) NextPatch() *Version {
+	if v == nil {
+		return nil
+	}
+
+	if len(v.preRelease()) > 0 && v.isZeroAfter(sectionPatch) {
+		return v.Release()
+	}
+	return v.next(sectionPatch)
+}
+
+// NextPreRelease returns the next pre-release version.
+//
+// If the version is a pre-release, its last numeric identifier is incremented, such as 1.0.0-rc.2 for 1.0.0-rc.1.
+// If its last identifier is not numeric, 0 is appended, such as 1.0.0-rc.0 for 1.0.0-rc.
+// If the version is not a pre-release, the first pre-release of the next patch version is returned, such as 1.2.4-0 for 1.2.3.
+//
+// To start a new pre-release series, use WithPreRelease instead.
+//
+// The returned version has no build metadata or Text.
+func (v *Version) NextPreRelease() *Version {
+	if v == nil {
+		return nil
+	}
+
+	preRelease := v.preRelease()
+	if len(preRelease) == 0 {
+		return v.NextPatch().WithPreRelease("0")
+	}
+
+	next := make([]Identifier, len(preRelease))
+	copy(next, preRelease)
+	if last := next[len(next)-1]; last.Numeric() {
+		next[len(next)-1] = Identifier(incrementDigits(string(last)))
+	} else {
+		next = append(next, "0")
+	}
+	return v.WithPreRelease(next...)
+}
+
+// Release returns the release of this version, without any pre-release or build metadata, such as 1.0.0 for 1.0.0-rc.1+build.5.
+//
+// The returned version has no Text.
+func (v *Version) Release() *Version {
+	if v == nil {
+		return nil
+	}
+
+	return newVersion(v.Numbers())
+}
+
+// WithPreRelease returns a pre-release of this version with the given identifiers, such as 1.3.0-rc.1 for 1.3.0 and identifiers rc and 1.
+// This can be combined with other methods to start a new pre-release series, such as v.NextMinor().WithPreRelease("rc", "1").
+//
+// The returned version has no build metadata or Text.
+func (v *Version) WithPreRelease(ids ...Identifier) *Version {
+	if v == nil {
+		return nil
+	}
+
+	r := v.Release()
+	if len(ids) > 0 {
+		r.PreRelease = ids
+		r.Extension = formatExtension(ids, nil)
+	}
+	return r
+}
+
+// isZeroAfter determines whether every numeric component after the given section is zero, including any Segments.
+func (v *Version) isZeroAfter(section int) bool {
+	for i, n := range v.Numbers() {
+		if i > section && !n.isZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// next returns a version with the numeric component in the given section incremented, and any lower components set to zero.
+func (v *Version) next(section int) *Version {
+	numbers := [3]Number{}
+	for i := sectionMajor; i < section; i++ {
+		numbers[i] = v.number(i)
+	}
+	numbers[section] = v.number(section).next()
+	return newVersion(numbers[:])
+}
+
+// incrementDigits adds one to a string of decimal digits of any length.
+func incrementDigits(str string) string {
+	digits := []byte(str)
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] < '9' {
+			digits[i]++
+			return string(digits)
+		}
+		digits[i] = '0'
+	}
+	return "1" + string(digits)
+}
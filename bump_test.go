@@ -0,0 +1,60 @@
+package version
+
+import (
+	"testing"
+)
+
+func TestVersion_Next(t *testing.T) {
+	type TestCase struct {
+		Input    *Version
+		Next     func(*Version) *Version
+		Expected string
+	}
+
+	testCases := []TestCase{
+		{Input: MustParse("v1.2.3+build.5"), Next: (*Version).NextMajor, Expected: "2.0.0"},
+		{Input: MustParse("1.2.3-rc.1"), Next: (*Version).NextMajor, Expected: "2.0.0"},
+		{Input: MustParse("2.0.0-rc.1"), Next: (*Version).NextMajor, Expected: "2.0.0"},
+		{Input: MustParse("v1.2.3+build.5"), Next: (*Version).NextMinor, Expected: "1.3.0"},
+		{Input: MustParse("1.2.3-rc.1"), Next: (*Version).NextMinor, Expected: "1.3.0"},
+		{Input: MustParse("1.3.0-rc.1"), Next: (*Version).NextMinor, Expected: "1.3.0"},
+		{Input: MustParse("v1.2.3+build.5"), Next: (*Version).NextPatch, Expected: "1.2.4"},
+		{Input: MustParse("1.2.4-rc.1"), Next: (*Version).NextPatch, Expected: "1.2.4"},
+		{Input: MustParse("1.0.0-rc.1"), Next: (*Version).NextPreRelease, Expected: "1.0.0-rc.2"},
+		{Input: MustParse("1.0.0-rc.9+build.5"), Next: (*Version).NextPreRelease, Expected: "1.0.0-rc.10"},
+		{Input: MustParse("1.0.0-rc"), Next: (*Version).NextPreRelease, Expected: "1.0.0-rc.0"},
+		{Input: MustParse("1.0.0-alpha.1.beta"), Next: (*Version).NextPreRelease, Expected: "1.0.0-alpha.1.beta.0"},
+		{Input: MustParse("1.0.0-99999999999999999999"), Next: (*Version).NextPreRelease, Expected: "1.0.0-100000000000000000000"},
+		{Input: MustParse("v1.2.3"), Next: (*Version).NextPreRelease, Expected: "1.2.4-0"},
+		{Input: MustParse("1.2.99999999999999999999"), Next: (*Version).NextPatch, Expected: "1.2.100000000000000000000"},
+		{Input: MustParse("1.2.9223372036854775807"), Next: (*Version).NextPatch, Expected: "1.2.9223372036854775808"},
+		{Input: MustParse("99999999999999999999.2.3"), Next: (*Version).NextMinor, Expected: "99999999999999999999.3.0"},
+		{Input: MustParse("99999999999999999999.2.3"), Next: (*Version).NextMajor, Expected: "100000000000000000000.0.0"},
+		{Input: MustParse("99999999999999999999.2.3-rc.1"), Next: (*Version).Release, Expected: "99999999999999999999.2.3"},
+		{Input: MustParse("1.0.0-rc.1+build.5"), Next: (*Version).Release, Expected: "1.0.0"},
+		{Input: MustParse("1.2.3.4-rc.1"), Next: (*Version).Release, Expected: "1.2.3.4"},
+		{Input: MustParse("1.2.3.4"), Next: (*Version).NextPatch, Expected: "1.2.4"},
+		{Input: MustParse("1.0.0.5-rc.1"), Next: (*Version).NextMajor, Expected: "2.0.0"},
+		{Input: MustParse("2.0.0.0-rc.1"), Next: (*Version).NextMajor, Expected: "2.0.0.0"},
+		{Input: MustParse("1.3.0.0.5-rc.1"), Next: (*Version).NextMinor, Expected: "1.4.0"},
+		{Input: MustParse("1.3.0.0-rc.1"), Next: (*Version).NextMinor, Expected: "1.3.0.0"},
+		{Input: MustParse("1.2.3.4-rc.1"), Next: (*Version).NextPatch, Expected: "1.2.4"},
+		{Input: MustParse("1.2.4.0-rc.1"), Next: (*Version).NextPatch, Expected: "1.2.4.0"},
+		{Input: MustParse("v1.2.3"), Next: func(v *Version) *Version { return v.NextMinor().WithPreRelease("rc", "1") }, Expected: "1.3.0-rc.1"},
+		{Input: MustParse("1.3.0-beta.4"), Next: func(v *Version) *Version { return v.WithPreRelease("rc", "1") }, Expected: "1.3.0-rc.1"},
+		{Input: MustParse("1.3.0-beta.4"), Next: func(v *Version) *Version { return v.WithPreRelease() }, Expected: "1.3.0"},
+	}
+
+	for i, testCase := range testCases {
+		text := testCase.Input.String()
+		actual := testCase.Next(testCase.Input)
+
+		if actual.String() != testCase.Expected || actual.Text != "" || actual.Extension != formatExtension(actual.PreRelease, actual.Build) {
+			t.Errorf("test %d failed (expected %s, actual %s with Text %q and Extension %q)", i, testCase.Expected, actual, actual.Text, actual.Extension)
+		} else if testCase.Input.String() != text {
+			t.Errorf("test %d failed (input modified from %s to %s)", i, text, testCase.Input)
+		} else {
+			t.Logf("test %d passed with %s", i, actual)
+		}
+	}
+}
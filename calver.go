@@ -0,0 +1,488 @@
+package version
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// calVerTokens are the components of a CalVer format, as described at https://calver.org/#scheme.
+var calVerTokens = []string{"YYYY", "YY", "0Y", "MM", "0M", "WW", "0W", "DD", "0D", "MAJOR", "MINOR", "MICRO"}
+
+// calVerPart is a component of a CalVer format, with the separator that precedes it.
+type calVerPart struct {
+	sep   string
+	token string
+}
+
+// CalVerFormat is a calendar versioning scheme, such as YYYY.0M.MICRO.
+// See https://calver.org/
+type CalVerFormat struct {
+	parts []calVerPart
+	text  string
+}
+
+// CalVer is a calendar version, such as 2024.10.3 in the format YYYY.0M.MICRO.
+// Components that are not in the format are zero.
+type CalVer struct {
+	Format *CalVerFormat
+
+	Year  int // Full year, such as 2024 for both 2024.10 and 24.10. In a format with a week, this is the ISO 8601 year.
+	Month int // Month, from 1 to 12.
+	Week  int // ISO 8601 week, from 1 to 53.
+	Day   int // Day of the month, from 1 to 31.
+
+	Major int
+	Minor int
+	Micro int
+
+	Text string // Original version string, if this version was created via the CalVerFormat.Parse function.
+}
+
+// MustParseCalVerFormat parses a CalVer format, and panics if it is invalid.
+func MustParseCalVerFormat(str string) *CalVerFormat {
+	f, err := ParseCalVerFormat(str)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// ParseCalVerFormat parses a CalVer format, such as YYYY.0M.MICRO or YY.MM.
+//
+// The format is made up of the following components, separated by a period, hyphen or underscore:
+//
+//   - YYYY: full year, such as 2006 or 2106
+//   - YY: short year, such as 6 or 106
+//   - 0Y: zero-padded short year, such as 06 or 106
+//   - MM and 0M: month, such as 1 or 01
+//   - WW and 0W: ISO 8601 week, such as 1 or 01
+//   - DD and 0D: day of the month, such as 1 or 01
+//   - MAJOR, MINOR and MICRO: counters, such as 0 or 12
+//
+// A format must have exactly one year, and may not have more than one of any other component.
+// A week cannot be combined with a month or day, and a day requires a month.
+func ParseCalVerFormat(str string) (*CalVerFormat, error) {
+	f := &CalVerFormat{text: str}
+	seen := map[string]bool{}
+	sep := ""
+	for i := 0; i < len(str); {
+		if len(f.parts) > 0 && sep == "" {
+			if c := str[i]; c != '.' && c != '-' && c != '_' {
+				return nil, newParseError(ErrInvalidCalVerFormat, KindInvalidCharacter, str, SectionSegment, i)
+			}
+			sep = str[i : i+1]
+			i++
+			continue
+		}
+
+		token := ""
+		for _, t := range calVerTokens {
+			if strings.HasPrefix(str[i:], t) {
+				token = t
+				break
+			}
+		}
+		if token == "" {
+			return nil, newParseError(ErrInvalidCalVerFormat, KindInvalidCharacter, str, SectionSegment, i)
+		}
+
+		kind := calVerField(token)
+		if kind == "counter" {
+			kind = token
+		}
+		if seen[kind] {
+			return nil, newParseError(ErrInvalidCalVerFormat, KindNone, str, SectionSegment, i)
+		}
+		seen[kind] = true
+
+		f.parts = append(f.parts, calVerPart{sep: sep, token: token})
+		sep = ""
+		i += len(token)
+	}
+
+	if len(f.parts) == 0 || sep != "" || !seen["year"] || seen["week"] && (seen["month"] || seen["day"]) || seen["day"] && !seen["month"] {
+		return nil, newError(ErrInvalidCalVerFormat, str)
+	}
+	return f, nil
+}
+
+// Compare a version (a) with another version (b), as described by CalVer.Compare.
+// This method and Format, Parse and Validate allow the format to be used as a Scheme.
+func (f *CalVerFormat) Compare(a, b *CalVer) int {
+	return a.Compare(b)
+}
+
+// Format returns a version formatted according to the format, such as 2024.01.3 in the format YYYY.0M.MICRO.
+func (f *CalVerFormat) Format(v *CalVer) string {
+	if v == nil {
+		return ""
+	}
+
+	w := *v
+	w.Format = f
+	return w.SemanticString()
+}
+
+// FromTime returns the first version in the format for a date, with all counters set to zero.
+// For example, the format YYYY.0M.MICRO gives 2024.10.0 for any date in October 2024.
+//
+// Short years count from 2000, so an ErrCalVerYear error is returned for a date before 2000 in a format such as YY.0M.
+func (f *CalVerFormat) FromTime(t time.Time) (*CalVer, error) {
+	v := &CalVer{Format: f, Year: t.Year()}
+	for _, p := range f.parts {
+		switch calVerField(p.token) {
+		case "month":
+			v.Month = int(t.Month())
+		case "week":
+			v.Year, v.Week = t.ISOWeek()
+		case "day":
+			v.Day = t.Day()
+		}
+	}
+
+	for _, p := range f.parts {
+		if _, ok := calVerValue(p.token, v.Year); !ok {
+			return nil, newError(ErrCalVerYear, strconv.Itoa(v.Year))
+		}
+	}
+	return v, nil
+}
+
+// MustParse parses a version in the format, and panics if it is invalid.
+func (f *CalVerFormat) MustParse(str string) *CalVer {
+	v, err := f.Parse(str)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Parse parses a version in the format.
+//
+// Each component must be a number in the form given by the format, so the format YYYY.0M requires a zero-padded month, such as 2024.01, and rejects 2024.1.
+// Leading zeros are not allowed in any other component.
+// The date must also be valid, so 2023.02.29 is rejected in the format YYYY.0M.0D.
+func (f *CalVerFormat) Parse(str string) (*CalVer, error) {
+	v := &CalVer{Format: f, Text: str}
+
+	i := 0
+	for _, p := range f.parts {
+		if i == len(str) && i > 0 {
+			return nil, newParseError(ErrInvalidCalVer, KindEmpty, str, SectionSegment, i)
+		} else if !strings.HasPrefix(str[i:], p.sep) {
+			return nil, newParseError(ErrInvalidCalVer, KindInvalidCharacter, str, SectionSegment, i)
+		}
+		i += len(p.sep)
+
+		start := i
+		for i < len(str) && isDigit(str[i]) {
+			i++
+		}
+		digits := str[start:i]
+		if digits == "" {
+			if i < len(str) {
+				return nil, newParseError(ErrInvalidCalVer, KindNonNumeric, str, SectionSegment, i)
+			}
+			return nil, newParseError(ErrInvalidCalVer, KindEmpty, str, SectionSegment, i)
+		}
+
+		n, err := strconv.Atoi(digits)
+		if err != nil {
+			return nil, newParseError(ErrInvalidCalVer, KindOverflow, str, SectionSegment, start)
+		}
+		if !calVerWidth(p.token, digits) {
+			return nil, newParseError(ErrInvalidCalVer, KindLeadingZero, str, SectionSegment, start)
+		}
+		if !v.set(p.token, n) {
+			return nil, newParseError(ErrInvalidCalVer, KindNone, str, SectionSegment, start)
+		}
+	}
+	if i < len(str) {
+		return nil, newParseError(ErrInvalidCalVer, KindInvalidCharacter, str, SectionSegment, i)
+	}
+
+	if v.Day > 0 && v.Time().Day() != v.Day {
+		return nil, newError(ErrInvalidCalVer, str)
+	}
+	if v.Week > 0 {
+		if year, _ := v.Time().ISOWeek(); year != v.Year {
+			return nil, newError(ErrInvalidCalVer, str)
+		}
+	}
+
+	return v, nil
+}
+
+// Validate checks that a version is valid in the format, returning the same error as Parse.
+func (f *CalVerFormat) Validate(str string) error {
+	_, err := f.Parse(str)
+	return err
+}
+
+func (f *CalVerFormat) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.text
+}
+
+// Compare this version (a) with another version (b).
+// This function returns -1 if a is less than b, 1 if a is greater than b, or 0 if a is equal to b.
+//
+// Components are compared numerically, in the order they appear in the format of a.
+// Both versions should have the same format.
+// If a has no format, components are compared by date and then by counter.
+//
+// A nil version is less than any other version.
+func (a *CalVer) Compare(b *CalVer) int {
+	if a == nil {
+		if b == nil {
+			return 0
+		}
+		return -1
+	} else if b == nil {
+		return 1
+	}
+
+	for _, token := range a.tokens() {
+		if cmp := sign(a.get(token) - b.get(token)); cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
+// Equal determines whether this version (a) is equal to another version (b).
+func (a *CalVer) Equal(b *CalVer) bool {
+	return a.Compare(b) == 0
+}
+
+// Less determines whether this version (a) is less than another version (b).
+func (a *CalVer) Less(b *CalVer) bool {
+	return a.Compare(b) < 0
+}
+
+// Match tests the version against a constraint, such as a ConstraintOf.
+// If the constraint is nil, this function returns true.
+func (v *CalVer) Match(m MatcherOf[*CalVer]) bool {
+	if v == nil {
+		return false
+	}
+	if m == nil {
+		return true
+	}
+	return m.Match(v)
+}
+
+// Next returns the next version for a date.
+//
+// If the date falls in a later period than the version, such as a later month in the format YYYY.0M.MICRO, the first version for that date is returned, as given by FromTime.
+// If the date falls in the same period as the version, the last counter in the format is incremented instead, such as 2024.10.4 for 2024.10.3.
+//
+// An ErrCalVerNext error is returned if the date falls in an earlier period, or in the same period in a format with no counters, or if the version has no format.
+// An ErrCalVerYear error is returned if the year of the date cannot be written in the format, as described by FromTime.
+//
+// The returned version has no Text.
+func (v *CalVer) Next(t time.Time) (*CalVer, error) {
+	if v == nil || v.Format == nil {
+		return nil, newError(ErrCalVerNext, v.String())
+	}
+	next, err := v.Format.FromTime(t)
+	if err != nil {
+		return nil, err
+	}
+
+	cmp := 0
+	for _, token := range v.tokens() {
+		if calVerField(token) == "counter" {
+			continue
+		}
+		if cmp = sign(next.get(token) - v.get(token)); cmp != 0 {
+			break
+		}
+	}
+	if cmp > 0 {
+		return next, nil
+	} else if cmp < 0 {
+		return nil, newError(ErrCalVerNext, v.String())
+	}
+
+	tokens := v.tokens()
+	for i := len(tokens) - 1; i >= 0; i-- {
+		if calVerField(tokens[i]) == "counter" {
+			next := *v
+			next.set(tokens[i], v.get(tokens[i])+1)
+			next.Text = ""
+			return &next, nil
+		}
+	}
+	return nil, newError(ErrCalVerNext, v.String())
+}
+
+// SemanticString returns the version formatted according to its format, such as 2024.01.3 in the format YYYY.0M.MICRO.
+// If the version has no format, its date and counters are joined with periods.
+// If its year cannot be written in its format, such as 1999 in the format YY.0M, an empty string is returned.
+func (v *CalVer) SemanticString() string {
+	if v == nil {
+		return ""
+	}
+
+	str := strings.Builder{}
+	if v.Format == nil {
+		for i, token := range v.tokens() {
+			if i > 0 {
+				str.WriteByte('.')
+			}
+			str.WriteString(strconv.Itoa(v.get(token)))
+		}
+		return str.String()
+	}
+
+	for _, p := range v.Format.parts {
+		str.WriteString(p.sep)
+		n, ok := calVerValue(p.token, v.get(p.token))
+		if !ok {
+			return ""
+		}
+		digits := strconv.Itoa(n)
+		if p.token[0] == '0' && len(digits) < 2 {
+			digits = "0" + digits
+		}
+		str.WriteString(digits)
+	}
+	return str.String()
+}
+
+func (v *CalVer) String() string {
+	if v == nil {
+		return ""
+	}
+
+	if v.Text != "" {
+		return v.Text
+	}
+
+	return v.SemanticString()
+}
+
+// Time returns the start of the period of the version, in UTC.
+// For example, 2024.10.3 in the format YYYY.0M.MICRO gives midnight on 1 October 2024, and 2024.42 in the format YYYY.0W gives midnight on Monday 14 October 2024.
+func (v *CalVer) Time() time.Time {
+	if v == nil {
+		return time.Time{}
+	}
+
+	if v.Week > 0 {
+		// Week 1 is the week containing 4 January
+		jan4 := time.Date(v.Year, time.January, 4, 0, 0, 0, 0, time.UTC)
+		monday := jan4.AddDate(0, 0, -(int(jan4.Weekday())+6)%7)
+		return monday.AddDate(0, 0, (v.Week-1)*7)
+	}
+	return time.Date(v.Year, time.Month(max(v.Month, 1)), max(v.Day, 1), 0, 0, 0, 0, time.UTC)
+}
+
+// get returns the value of the version for a component of its format.
+// Short years are given as full years.
+func (v *CalVer) get(token string) int {
+	switch calVerField(token) {
+	case "year":
+		return v.Year
+	case "month":
+		return v.Month
+	case "week":
+		return v.Week
+	case "day":
+		return v.Day
+	}
+	switch token {
+	case "MAJOR":
+		return v.Major
+	case "MINOR":
+		return v.Minor
+	}
+	return v.Micro
+}
+
+// set sets the value of the version for a component of its format.
+// If the value is out of range for the component, false is returned.
+func (v *CalVer) set(token string, n int) bool {
+	switch token {
+	case "YY", "0Y":
+		n += 2000
+	}
+
+	switch calVerField(token) {
+	case "year":
+		v.Year = n
+	case "month":
+		v.Month = n
+		return n >= 1 && n <= 12
+	case "week":
+		v.Week = n
+		return n >= 1 && n <= 53
+	case "day":
+		v.Day = n
+		return n >= 1 && n <= 31
+	default:
+		switch token {
+		case "MAJOR":
+			v.Major = n
+		case "MINOR":
+			v.Minor = n
+		default:
+			v.Micro = n
+		}
+	}
+	return true
+}
+
+// tokens returns the components of the format of the version, or all components in order of significance if it has no format.
+func (v *CalVer) tokens() []string {
+	if v.Format == nil {
+		return []string{"YYYY", "MM", "WW", "DD", "MAJOR", "MINOR", "MICRO"}
+	}
+
+	tokens := make([]string, len(v.Format.parts))
+	for i, p := range v.Format.parts {
+		tokens[i] = p.token
+	}
+	return tokens
+}
+
+// calVerField returns the kind of value represented by a component of a CalVer format: year, month, week, day or counter.
+func calVerField(token string) string {
+	switch token {
+	case "YYYY", "YY", "0Y":
+		return "year"
+	case "MM", "0M":
+		return "month"
+	case "WW", "0W":
+		return "week"
+	case "DD", "0D":
+		return "day"
+	}
+	return "counter"
+}
+
+// calVerValue returns the value written for a component of a CalVer format, which differs from the value of the version for short years only.
+// Short years count from 2000, so a year before 2000 cannot be written, and false is returned.
+func calVerValue(token string, n int) (int, bool) {
+	switch token {
+	case "YY", "0Y":
+		return n - 2000, n >= 2000
+	}
+	return n, true
+}
+
+// calVerWidth determines whether the digits of a component have the width required by the format.
+// Zero-padded components have at least two digits, full years have at least four, and no other component has leading zeros.
+func calVerWidth(token, digits string) bool {
+	switch {
+	case token == "YYYY":
+		return len(digits) >= 4 && digits[0] != '0'
+	case token[0] == '0':
+		return len(digits) == 2 || len(digits) > 2 && digits[0] != '0'
+	}
+	return len(digits) == 1 || digits[0] != '0'
+}
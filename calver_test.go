@@ -0,0 +1,226 @@
+package version
+
+import (
+	"errors"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestParseCalVerFormat(t *testing.T) {
+	type TestCase struct {
+		Input string
+		Err   error
+	}
+
+	testCases := []TestCase{
+		{Input: "YYYY.0M.MICRO"},
+		{Input: "YY.MM"},
+		{Input: "YYYY.0M.0D-MICRO"},
+		{Input: "0Y_0W"},
+		{Input: "YYYY.MAJOR.MINOR.MICRO"},
+		{Input: "", Err: ErrInvalidCalVerFormat},
+		{Input: "MAJOR.MINOR", Err: ErrInvalidCalVerFormat},
+		{Input: "YYYY.YY", Err: ErrInvalidCalVerFormat},
+		{Input: "YYYY.MM.0M", Err: ErrInvalidCalVerFormat},
+		{Input: "YYYY.MICRO.MICRO", Err: ErrInvalidCalVerFormat},
+		{Input: "YYYY.WW.DD", Err: ErrInvalidCalVerFormat},
+		{Input: "YYYY.DD", Err: ErrInvalidCalVerFormat},
+		{Input: "YYYY0M", Err: ErrInvalidCalVerFormat},
+		{Input: "YYYY..MM", Err: ErrInvalidCalVerFormat},
+		{Input: "YYYY.MM.", Err: ErrInvalidCalVerFormat},
+		{Input: "YYYY.MONTH", Err: ErrInvalidCalVerFormat},
+	}
+
+	for i, testCase := range testCases {
+		actual, err := ParseCalVerFormat(testCase.Input)
+
+		if testCase.Err != nil {
+			if !errors.Is(err, testCase.Err) {
+				t.Errorf("test %d failed (expected error %s, actual %v)", i, testCase.Err, err)
+			} else {
+				t.Logf("test %d passed with error %s for %q\n", i, err, testCase.Input)
+			}
+		} else if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+		} else if actual.String() != testCase.Input {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, testCase.Input, actual)
+		} else {
+			t.Logf("test %d passed with %s", i, actual)
+		}
+	}
+}
+
+func TestCalVerFormat_Parse(t *testing.T) {
+	type TestCase struct {
+		Format   string
+		Input    string
+		Expected CalVer
+		Err      error
+	}
+
+	testCases := []TestCase{
+		{Format: "YYYY.0M.MICRO", Input: "2024.10.3", Expected: CalVer{Year: 2024, Month: 10, Micro: 3}},
+		{Format: "YY.0M", Input: "24.04", Expected: CalVer{Year: 2024, Month: 4}},
+		{Format: "YYYY.0M.0D-MICRO", Input: "2024.10.18-1", Expected: CalVer{Year: 2024, Month: 10, Day: 18, Micro: 1}},
+		{Format: "YY.MM", Input: "6.1", Expected: CalVer{Year: 2006, Month: 1}},
+		{Format: "0Y.0W", Input: "26.53", Expected: CalVer{Year: 2026, Week: 53}},
+		{Format: "0Y.MM", Input: "106.1", Expected: CalVer{Year: 2106, Month: 1}},
+		{Format: "YYYY.0M.0D", Input: "2024.02.29", Expected: CalVer{Year: 2024, Month: 2, Day: 29}},
+		{Format: "YYYY.MAJOR.MINOR", Input: "2024.10.0", Expected: CalVer{Year: 2024, Major: 10}},
+		{Format: "YYYY.0M.MICRO", Input: "2024.1.3", Err: ErrLeadingZero},
+		{Format: "YY.MM", Input: "24.04", Err: ErrLeadingZero},
+		{Format: "YYYY.MM", Input: "24.4", Err: ErrLeadingZero},
+		{Format: "YYYY.MM", Input: "2024.13", Err: ErrInvalidCalVer},
+		{Format: "YYYY.0M.0D", Input: "2023.02.29", Err: ErrInvalidCalVer},
+		{Format: "YYYY.0W", Input: "2024.53", Err: ErrInvalidCalVer},
+		{Format: "YYYY.0M", Input: "2024.10.1", Err: ErrInvalidCharacter},
+		{Format: "YYYY.0M.0D-MICRO", Input: "2024.10.18.1", Err: ErrInvalidCharacter},
+		{Format: "YYYY.0M.MICRO", Input: "2024.10", Err: ErrEmpty},
+		{Format: "YYYY.0M.MICRO", Input: "2024.10.rc1", Err: ErrNonNumeric},
+	}
+
+	for i, testCase := range testCases {
+		actual, err := MustParseCalVerFormat(testCase.Format).Parse(testCase.Input)
+
+		if testCase.Err != nil {
+			if !errors.Is(err, testCase.Err) {
+				t.Errorf("test %d failed (expected error %s, actual %v)", i, testCase.Err, err)
+			} else {
+				t.Logf("test %d passed with error %s for %q\n", i, err, testCase.Input)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+			continue
+		}
+
+		expected := testCase.Expected
+		expected.Format = actual.Format
+		expected.Text = testCase.Input
+		if *actual != expected {
+			t.Errorf("test %d failed (expected %+v, actual %+v)", i, expected, *actual)
+		} else if actual.SemanticString() != testCase.Input {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, testCase.Input, actual.SemanticString())
+		} else {
+			t.Logf("test %d passed with %s", i, actual)
+		}
+	}
+}
+
+func TestCalVer_Compare(t *testing.T) {
+	f := MustParseCalVerFormat("YYYY.MM.MICRO")
+	ordered := []string{"2023.12.5", "2024.1.0", "2024.1.2", "2024.1.10", "2024.10.0", "2025.1.0"}
+
+	for i := 1; i < len(ordered); i++ {
+		a := f.MustParse(ordered[i-1])
+		b := f.MustParse(ordered[i])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("test %d failed (expected %s < %s)", i, a, b)
+		}
+	}
+
+	list := ListOf[*CalVer]{}
+	for i := len(ordered) - 1; i >= 0; i-- {
+		list = append(list, f.MustParse(ordered[i]))
+	}
+	sort.Sort(list)
+	for i, v := range list {
+		if v.Text != ordered[i] {
+			t.Errorf("sort failed at position %d (expected %s, actual %s)", i, ordered[i], v)
+		}
+	}
+
+	upper, _ := f.FromTime(time.Date(2024, time.October, 18, 0, 0, 0, 0, time.UTC))
+	r := &ConstraintOf[*CalVer]{Gte: f.MustParse("2024.1.0"), Lt: upper}
+	if matched := list.Match(r); len(matched) != 3 || matched[2].String() != "2024.1.10" {
+		t.Errorf("range match failed (actual %v)", matched)
+	}
+}
+
+func TestCalVer_Time(t *testing.T) {
+	type TestCase struct {
+		Format   string
+		Input    string
+		Expected time.Time
+	}
+
+	testCases := []TestCase{
+		{Format: "YYYY.0M.MICRO", Input: "2024.10.3", Expected: time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC)},
+		{Format: "YY.0M.0D", Input: "24.10.18", Expected: time.Date(2024, time.October, 18, 0, 0, 0, 0, time.UTC)},
+		{Format: "YYYY.0W", Input: "2024.42", Expected: time.Date(2024, time.October, 14, 0, 0, 0, 0, time.UTC)},
+		{Format: "YYYY.WW", Input: "2021.1", Expected: time.Date(2021, time.January, 4, 0, 0, 0, 0, time.UTC)},
+		{Format: "YYYY.MICRO", Input: "2024.7", Expected: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for i, testCase := range testCases {
+		f := MustParseCalVerFormat(testCase.Format)
+		actual := f.MustParse(testCase.Input).Time()
+
+		if !actual.Equal(testCase.Expected) {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, testCase.Expected, actual)
+		} else if v, err := f.FromTime(actual); err != nil || v.Time() != actual {
+			t.Errorf("test %d failed (expected %s to round trip, actual %s)", i, actual, v.Time())
+		} else {
+			t.Logf("test %d passed with %s", i, actual)
+		}
+	}
+
+	// The ISO week of 1 January 2021 belongs to 2020
+	if v, _ := MustParseCalVerFormat("YYYY.0W").FromTime(time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)); v.String() != "2020.53" {
+		t.Errorf("expected 2020.53, actual %s", v)
+	}
+
+	// Short years count from 2000
+	if v, err := MustParseCalVerFormat("YY.0M").FromTime(time.Date(1999, time.December, 31, 0, 0, 0, 0, time.UTC)); !errors.Is(err, ErrCalVerYear) {
+		t.Errorf("expected error %s, actual %v (version %s)", ErrCalVerYear, err, v)
+	}
+	if str := (&CalVer{Format: MustParseCalVerFormat("0Y.0M"), Year: 1999, Month: 12}).SemanticString(); str != "" {
+		t.Errorf("expected empty string for 1999, actual %s", str)
+	}
+}
+
+func TestCalVer_Next(t *testing.T) {
+	type TestCase struct {
+		Format   string
+		Input    string
+		Date     time.Time
+		Expected string
+		Err      error
+	}
+
+	oct18 := time.Date(2024, time.October, 18, 12, 0, 0, 0, time.UTC)
+
+	testCases := []TestCase{
+		{Format: "YYYY.0M.MICRO", Input: "2024.10.3", Date: oct18, Expected: "2024.10.4"},
+		{Format: "YYYY.0M.MICRO", Input: "2024.09.3", Date: oct18, Expected: "2024.10.0"},
+		{Format: "YYYY.0M.0D-MICRO", Input: "2024.10.18-1", Date: oct18, Expected: "2024.10.18-2"},
+		{Format: "YYYY.0M.0D-MICRO", Input: "2024.10.17-1", Date: oct18, Expected: "2024.10.18-0"},
+		{Format: "YY.MM", Input: "24.9", Date: oct18, Expected: "24.10"},
+		{Format: "YYYY.MINOR.MICRO", Input: "2024.2.5", Date: oct18, Expected: "2024.2.6"},
+		{Format: "YY.MM", Input: "24.10", Date: oct18, Err: ErrCalVerNext},
+		{Format: "YYYY.0M.MICRO", Input: "2024.11.0", Date: oct18, Err: ErrCalVerNext},
+		{Format: "YY.0M.MICRO", Input: "0.01.0", Date: time.Date(1999, time.December, 31, 0, 0, 0, 0, time.UTC), Err: ErrCalVerYear},
+	}
+
+	for i, testCase := range testCases {
+		v := MustParseCalVerFormat(testCase.Format).MustParse(testCase.Input)
+		actual, err := v.Next(testCase.Date)
+
+		if testCase.Err != nil {
+			if !errors.Is(err, testCase.Err) {
+				t.Errorf("test %d failed (expected error %s, actual %v)", i, testCase.Err, err)
+			} else {
+				t.Logf("test %d passed with error %s for %q\n", i, err, testCase.Input)
+			}
+		} else if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+		} else if actual.String() != testCase.Expected {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, testCase.Expected, actual)
+		} else if !v.Less(actual) {
+			t.Errorf("test %d failed (expected %s < %s)", i, v, actual)
+		} else {
+			t.Logf("test %d passed with %s", i, actual)
+		}
+	}
+}
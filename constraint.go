@@ -1,9 +1,562 @@
 package version
 
+import (
+	"errors"
+	"strings"
+)
+
 // Constraint enables matching a version based on lower and upper bounds.
-type Constraint struct {
-	Gt  *Version // Greater than...
-	Gte *Version // Greater than or equal to...
-	Lt  *Version // Less than...
-	Lte *Version // Less than or equal to...
+type Constraint = ConstraintOf[*Version]
+
+// ConstraintOf enables matching a version of any comparable type based on lower and upper bounds.
+// A nil bound is unset.
+type ConstraintOf[V Comparable[V]] struct {
+	Gt  V // Greater than...
+	Gte V // Greater than or equal to...
+	Lt  V // Less than...
+	Lte V // Less than or equal to...
+}
+
+// Match tests a version against the constraint.
+// Gt and Lt take precedence over Gte and Lte.
+//
+// A nil Constraint matches any version.
+func (c *ConstraintOf[V]) Match(v V) bool {
+	if isNil(v) {
+		return false
+	}
+
+	if c == nil {
+		return true
+	}
+
+	if !isNil(c.Gt) {
+		if v.Compare(c.Gt) <= 0 {
+			return false
+		}
+	} else if !isNil(c.Gte) {
+		if v.Compare(c.Gte) < 0 {
+			return false
+		}
+	}
+
+	if !isNil(c.Lt) {
+		if v.Compare(c.Lt) >= 0 {
+			return false
+		}
+	} else if !isNil(c.Lte) {
+		if v.Compare(c.Lte) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Complement returns the set of versions not matched by the constraint.
+func (c *ConstraintOf[V]) Complement() SetOf[V] {
+	return NewSetOf(c).Complement()
+}
+
+// Contains determines whether every version matched by another constraint is also matched by this constraint.
+func (c *ConstraintOf[V]) Contains(other *ConstraintOf[V]) bool {
+	return NewSetOf(c).Contains(NewSetOf(other))
+}
+
+// Intersect returns the set of versions matched by both this constraint and another constraint.
+func (c *ConstraintOf[V]) Intersect(other *ConstraintOf[V]) SetOf[V] {
+	return NewSetOf(c).Intersect(NewSetOf(other))
+}
+
+// IsEmpty determines whether the constraint cannot match any version.
+func (c *ConstraintOf[V]) IsEmpty() bool {
+	return NewSetOf(c).IsEmpty()
+}
+
+// Overlaps determines whether any version is matched by both this constraint and another constraint.
+func (c *ConstraintOf[V]) Overlaps(other *ConstraintOf[V]) bool {
+	return NewSetOf(c).Overlaps(NewSetOf(other))
+}
+
+// String returns the canonical form of the constraint, such as >=1.2.0 <2.0.0, which can be read back by ParseConstraint.
+// A constraint with no bounds is written as *, and a constraint matching a single version is written as =1.2.3.
+//
+// If Gte or Lte are set but ignored because Gt or Lt take precedence, they are flagged at the end of the string, such as >1.0.0 (ignored: >=0.9.0).
+// Such a string cannot be read back by ParseConstraint.
+func (c *ConstraintOf[V]) String() string {
+	if c == nil {
+		return "*"
+	}
+
+	str := c.interval().String()
+
+	ignored := []string{}
+	if !isNil(c.Gt) && !isNil(c.Gte) {
+		ignored = append(ignored, ">="+formatVersion(c.Gte))
+	}
+	if !isNil(c.Lt) && !isNil(c.Lte) {
+		ignored = append(ignored, "<="+formatVersion(c.Lte))
+	}
+	if len(ignored) > 0 {
+		str += " (ignored: " + strings.Join(ignored, " ") + ")"
+	}
+
+	return str
+}
+
+// Union returns the set of versions matched by either this constraint or another constraint.
+func (c *ConstraintOf[V]) Union(other *ConstraintOf[V]) SetOf[V] {
+	return NewSetOf(c).Union(NewSetOf(other))
+}
+
+// Validate checks the constraint for bounds that conflict with each other.
+//
+// An ErrDuplicateLowerBound or ErrDuplicateUpperBound error is returned if both Gt and Gte, or both Lt and Lte, are set, since Match ignores Gte and Lte in that case.
+// An ErrContradictoryBounds error is returned if the lower bound is greater than the upper bound, and an ErrEmptyRange error is returned if the bounds are equal but either is exclusive.
+// In either case, the constraint matches no versions.
+//
+// A single problem is returned as an Error, and if more than one problem is found, the errors are joined.
+// https://pkg.go.dev/errors#Join
+func (c *ConstraintOf[V]) Validate() error {
+	return c.validate(c.String())
+}
+
+// interval returns the effective bounds of the constraint.
+// Gt and Lt take precedence over Gte and Lte, as in Match.
+func (c *ConstraintOf[V]) interval() *interval[V] {
+	iv := &interval[V]{}
+	if c == nil {
+		return iv
+	}
+
+	if !isNil(c.Gt) {
+		iv.Lower = &bound[V]{Version: c.Gt}
+	} else if !isNil(c.Gte) {
+		iv.Lower = &bound[V]{Version: c.Gte, Inclusive: true}
+	}
+
+	if !isNil(c.Lt) {
+		iv.Upper = &bound[V]{Version: c.Lt}
+	} else if !isNil(c.Lte) {
+		iv.Upper = &bound[V]{Version: c.Lte, Inclusive: true}
+	}
+
+	return iv
+}
+
+// validate checks the constraint as described by Validate.
+// The given string is used to describe the constraint in any error.
+func (c *ConstraintOf[V]) validate(str string) error {
+	if c == nil {
+		return nil
+	}
+
+	errs := []error{}
+	if !isNil(c.Gt) && !isNil(c.Gte) {
+		errs = append(errs, newError(ErrDuplicateLowerBound, str))
+	}
+	if !isNil(c.Lt) && !isNil(c.Lte) {
+		errs = append(errs, newError(ErrDuplicateUpperBound, str))
+	}
+
+	iv := c.interval()
+	if iv.Lower != nil && iv.Upper != nil {
+		cmp := iv.Lower.Version.Compare(iv.Upper.Version)
+		if cmp > 0 {
+			errs = append(errs, newError(ErrContradictoryBounds, str))
+		} else if cmp == 0 && !(iv.Lower.Inclusive && iv.Upper.Inclusive) {
+			errs = append(errs, newError(ErrEmptyRange, str))
+		}
+	}
+
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
+
+// bound is one end of a version range.
+type bound[V any] struct {
+	Version   V
+	Inclusive bool
+}
+
+// partial is a possibly incomplete version used in a range expression, such as 1.2 or 1.x.
+type partial struct {
+	Numbers    []Number
+	N          int  // Number of numeric components specified before any wildcard.
+	Wildcard   bool // Whether an explicit wildcard follows the numeric components, such as 1.2.3.x.
+	PreRelease []Identifier
+	Build      []Identifier
+}
+
+// interval is a version range with optional lower and upper bounds.
+// A nil bound leaves that end of the range unbounded.
+type interval[V Comparable[V]] struct {
+	Lower *bound[V]
+	Upper *bound[V]
+}
+
+// ParseConstraint parses a version range expression, in the syntax commonly used by npm and Cargo.
+// The following forms are supported:
+//
+//	>=1.2.0 <2.0.0   Comparison operators (>, >=, <, <=, =), separated by spaces or commas
+//	^1.2.3           Caret: >=1.2.3 <2.0.0-0, or >=0.2.3 <0.3.0-0 for ^0.2.3
+//	~1.2             Tilde: >=1.2.0 <1.3.0-0
+//	1.x, 1.2.*       X-ranges: >=1.0.0 <2.0.0-0, >=1.2.0 <1.3.0-0
+//	1.2 - 1.4        Hyphen ranges: >=1.2.0 <1.5.0-0
+//	1.2.3            Exact version: >=1.2.3 <=1.2.3
+//	!=1.3.4          Exclusion of a version or x-range
+//	^1.2 || >=2.5    Unions of any of the above
+//
+// Versions with more than three numeric components are also supported, such as 1.2.3.4 or 1.2.3.x (>=1.2.3 <1.2.4-0).
+//
+// A single range is returned as a *Constraint.
+// A range that contains exclusions is returned as an Intersection, and ||-separated ranges are returned as a Union.
+//
+// Each range is validated as described by Constraint.Validate, so a range whose bounds contradict each other, such as >2.0.0 <1.0.0, is rejected.
+func ParseConstraint(str string) (Matcher, error) {
+	return parseConstraint(str, applyPartial)
+}
+
+// ParseConstraintOf parses a version range expression for versions of any comparable type, using a parse function such as ParseDebian.
+//
+// The syntax is the same as ParseConstraint, except that caret, tilde and x-ranges are not supported, since their meaning depends on the numbering of the version.
+// The version * matches any version, so !=* matches no versions.
+// A hyphen range such as 1.2 - 1.4 is read as >=1.2 <=1.4.
+//
+// A single range is returned as a *ConstraintOf.
+// A range that contains exclusions is returned as an IntersectionOf, and ||-separated ranges are returned as a UnionOf.
+func ParseConstraintOf[V Comparable[V]](parse func(string) (V, error), str string) (MatcherOf[V], error) {
+	return parseConstraint(str, func(b *interval[V], op, str string) error {
+		if str == "*" && (op == "" || op == "=") {
+			return nil
+		}
+
+		v, err := parse(str)
+		if err != nil {
+			return err
+		}
+		if op == "" {
+			op = "="
+		}
+		if !b.Apply(op, v) {
+			return newError(ErrInvalidConstraint, op+str)
+		}
+		return nil
+	})
+}
+
+// parseConstraint parses a version range expression, as described by ParseConstraint.
+// Each comparator is added to its range by the apply function, which parses the version and returns an error for any unsupported operator.
+func parseConstraint[V Comparable[V]](str string, apply func(b *interval[V], op, str string) error) (MatcherOf[V], error) {
+	u := UnionOf[V]{}
+
+	for _, r := range strings.Split(str, "||") {
+		m, c, err := parseRange(strings.TrimSpace(r), apply)
+		if err != nil {
+			return nil, newError(ErrInvalidConstraint, str)
+		}
+		if err := c.validate(str); err != nil {
+			return nil, err
+		}
+		u = append(u, m)
+	}
+
+	if len(u) == 1 {
+		return u[0], nil
+	}
+	return u, nil
+}
+
+// parseRange parses a single range, without any || unions.
+// The bounds of the range are also returned as a Constraint, without any exclusions.
+func parseRange[V Comparable[V]](str string, apply func(b *interval[V], op, str string) error) (MatcherOf[V], *ConstraintOf[V], error) {
+	b := &interval[V]{}
+	in := IntersectionOf[V]{}
+
+	if i := strings.Index(str, " - "); i > -1 {
+		// A hyphen range includes the whole of a partial upper bound, just as <= does
+		if err := apply(b, ">=", strings.TrimSpace(str[:i])); err != nil {
+			return nil, nil, err
+		}
+		if err := apply(b, "<=", strings.TrimSpace(str[i+3:])); err != nil {
+			return nil, nil, err
+		}
+		c := b.Constraint()
+		return c, c, nil
+	}
+
+	tokens := strings.Fields(strings.ReplaceAll(str, ",", " "))
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		op := comparisonOperator(token)
+		if op == token && i+1 < len(tokens) {
+			// Operator separated from its version by whitespace, such as >= 1.2.0
+			i++
+			token += tokens[i]
+		}
+
+		if op == "!=" {
+			excluded := &interval[V]{}
+			if err := apply(excluded, "=", token[len(op):]); err != nil {
+				return nil, nil, err
+			}
+			in = append(in, ExclusionOf[V]{Matcher: excluded.Constraint()})
+		} else if err := apply(b, op, token[len(op):]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	c := b.Constraint()
+	if len(in) > 0 {
+		return append(IntersectionOf[V]{c}, in...), c, nil
+	}
+	return c, c, nil
+}
+
+// applyPartial adds the bounds described by a single comparator to the range, where the version may be partial, such as 1.2 or 1.x.
+func applyPartial(b *interval[*Version], op, str string) error {
+	p, err := parsePartial(str)
+	if err != nil {
+		return err
+	}
+
+	switch op {
+	case "", "=":
+		if p.Complete() {
+			b.SetLower(p.Floor(), true)
+			b.SetUpper(p.Floor(), true)
+		} else if p.N > 0 {
+			b.SetLower(p.Floor(), true)
+			b.SetUpper(p.Next(p.N, "0"), false)
+		}
+	case ">":
+		if p.Complete() {
+			b.SetLower(p.Floor(), false)
+		} else if p.N > 0 {
+			b.SetLower(p.Next(p.N), true)
+		} else {
+			b.SetUpper(newVersion(nil, "0"), false)
+		}
+	case ">=":
+		if p.N > 0 {
+			b.SetLower(p.Floor(), true)
+		}
+	case "<":
+		if p.Complete() {
+			b.SetUpper(p.Floor(), false)
+		} else {
+			b.SetUpper(newVersion(p.Numbers, "0"), false)
+		}
+	case "<=":
+		if p.Complete() {
+			b.SetUpper(p.Floor(), true)
+		} else if p.N > 0 {
+			b.SetUpper(p.Next(p.N, "0"), false)
+		}
+	case "~":
+		if p.N > 0 {
+			b.SetLower(p.Floor(), true)
+			b.SetUpper(p.Next(min(p.N, 2), "0"), false)
+		}
+	case "^":
+		if p.N > 0 {
+			b.SetLower(p.Floor(), true)
+			// The first non-zero component may change, or the last one given if all are zero
+			n := 1
+			for n < p.N && p.Numbers[n-1].isZero() {
+				n++
+			}
+			b.SetUpper(p.Next(n, "0"), false)
+		}
+	}
+
+	return nil
+}
+
+// Apply adds the bounds described by a comparison operator and version to the range, for the operators >, >=, <, <= and =.
+// Bounds are only ever tightened, so applying several comparisons gives their intersection.
+//
+// If the operator is not supported, this function returns false and the range is unchanged.
+func (b *interval[V]) Apply(op string, v V) bool {
+	switch op {
+	case ">":
+		b.SetLower(v, false)
+	case ">=":
+		b.SetLower(v, true)
+	case "<":
+		b.SetUpper(v, false)
+	case "<=":
+		b.SetUpper(v, true)
+	case "=":
+		b.SetLower(v, true)
+		b.SetUpper(v, true)
+	default:
+		return false
+	}
+	return true
+}
+
+// Constraint returns a Constraint with the accumulated bounds.
+func (b *interval[V]) Constraint() *ConstraintOf[V] {
+	c := &ConstraintOf[V]{}
+	if b.Lower != nil {
+		if b.Lower.Inclusive {
+			c.Gte = b.Lower.Version
+		} else {
+			c.Gt = b.Lower.Version
+		}
+	}
+	if b.Upper != nil {
+		if b.Upper.Inclusive {
+			c.Lte = b.Upper.Version
+		} else {
+			c.Lt = b.Upper.Version
+		}
+	}
+	return c
+}
+
+// String returns the canonical form of the interval, as described by Constraint.String.
+func (b *interval[V]) String() string {
+	if b.Lower == nil && b.Upper == nil {
+		return "*"
+	}
+
+	if b.Lower != nil && b.Upper != nil && b.Lower.Inclusive && b.Upper.Inclusive && b.Lower.Version.Compare(b.Upper.Version) == 0 {
+		return "=" + formatVersion(b.Lower.Version)
+	}
+
+	strs := []string{}
+	if b.Lower != nil {
+		if b.Lower.Inclusive {
+			strs = append(strs, ">="+formatVersion(b.Lower.Version))
+		} else {
+			strs = append(strs, ">"+formatVersion(b.Lower.Version))
+		}
+	}
+	if b.Upper != nil {
+		if b.Upper.Inclusive {
+			strs = append(strs, "<="+formatVersion(b.Upper.Version))
+		} else {
+			strs = append(strs, "<"+formatVersion(b.Upper.Version))
+		}
+	}
+	return strings.Join(strs, " ")
+}
+
+// SetLower sets the lower bound of the range, if it is tighter than the current lower bound.
+func (b *interval[V]) SetLower(v V, inclusive bool) {
+	if b.Lower != nil {
+		cmp := v.Compare(b.Lower.Version)
+		if cmp < 0 || cmp == 0 && inclusive {
+			return
+		}
+	}
+	b.Lower = &bound[V]{Version: v, Inclusive: inclusive}
+}
+
+// SetUpper sets the upper bound of the range, if it is tighter than the current upper bound.
+func (b *interval[V]) SetUpper(v V, inclusive bool) {
+	if b.Upper != nil {
+		cmp := v.Compare(b.Upper.Version)
+		if cmp > 0 || cmp == 0 && inclusive {
+			return
+		}
+	}
+	b.Upper = &bound[V]{Version: v, Inclusive: inclusive}
+}
+
+// Complete returns true if the partial version specifies a single version, such as 1.2.3 or 1.2.3.4.
+func (p partial) Complete() bool {
+	return p.N >= 3 && !p.Wildcard
+}
+
+// Floor returns the lowest version described by the partial version, filling any missing components with zeros.
+func (p partial) Floor() *Version {
+	v := newVersion(p.Numbers)
+	if p.Complete() {
+		v.PreRelease = p.PreRelease
+		v.Build = p.Build
+		v.Extension = formatExtension(p.PreRelease, p.Build)
+	}
+	return v
+}
+
+// Next returns the version following the partial version in the given section, such as 2.0.0 for 1.2 and section 1.
+//
+// Upper bounds use the lowest pre-release of the next version, such as 2.0.0-0, so that its pre-releases are excluded from the range.
+func (p partial) Next(n int, preRelease ...Identifier) *Version {
+	numbers := append([]Number{}, p.Numbers[:n]...)
+	numbers[n-1] = numbers[n-1].next()
+	return newVersion(numbers, preRelease...)
+}
+
+// comparisonOperator returns the operator at the start of a comparator, if there is one.
+func comparisonOperator(str string) string {
+	for _, op := range []string{">=", "<=", "!=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(str, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// newVersion creates a version with the given numbers and pre-release identifiers.
+// Missing major, minor or patch numbers are zero, and any numbers after the patch number become Segments.
+func newVersion(numbers []Number, preRelease ...Identifier) *Version {
+	v := &Version{}
+	for i, n := range numbers {
+		if i <= sectionPatch {
+			v.setNumber(i, n.String())
+		} else {
+			v.Segments = append(v.Segments, n)
+		}
+	}
+	if len(preRelease) > 0 {
+		v.PreRelease = preRelease
+		v.Extension = formatExtension(preRelease, nil)
+	}
+	return v
+}
+
+// parsePartial parses a possibly incomplete version, in which missing components or wildcards (x, X or *) match any value.
+func parsePartial(str string) (partial, error) {
+	p := partial{}
+
+	if len(str) > 0 && strings.IndexByte("vV", str[0]) > -1 {
+		str = str[1:]
+	}
+	if len(str) == 0 {
+		return p, invalid(str)
+	}
+
+	core := str
+	ext := ""
+	if i := strings.IndexAny(str, "-+"); i > -1 {
+		core = str[:i]
+		ext = str[i:]
+	}
+
+	for _, part := range strings.Split(core, ".") {
+		if part == "x" || part == "X" || part == "*" {
+			p.Wildcard = true
+			continue
+		}
+		if p.Wildcard || !isNumeric(part) {
+			return p, invalid(str)
+		}
+		p.Numbers = append(p.Numbers, newNumber(part))
+		p.N++
+	}
+
+	if ext != "" {
+		if !p.Complete() {
+			return p, invalid(str)
+		}
+		p.PreRelease, p.Build = splitExtension(ext)
+	}
+
+	return p, nil
 }
@@ -0,0 +1,295 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseConstraint(t *testing.T) {
+	type TestCase struct {
+		Input    string
+		Expected []*Constraint
+		Err      error
+	}
+
+	testCases := []TestCase{
+		{Input: "", Expected: []*Constraint{{}}},
+		{Input: "*", Expected: []*Constraint{{}}},
+		{Input: "1.2.3", Expected: []*Constraint{{Gte: MustParse("1.2.3"), Lte: MustParse("1.2.3")}}},
+		{Input: "=v1.2.3-rc.1", Expected: []*Constraint{{Gte: MustParse("1.2.3-rc.1"), Lte: MustParse("1.2.3-rc.1")}}},
+		{Input: ">=1.2.0 <2.0.0", Expected: []*Constraint{{Gte: MustParse("1.2.0"), Lt: MustParse("2.0.0")}}},
+		{Input: ">= 1.2.0, < 2.0.0", Expected: []*Constraint{{Gte: MustParse("1.2.0"), Lt: MustParse("2.0.0")}}},
+		{Input: ">1.2.0 >=1.3.0 <=2.0.0 <1.9.0", Expected: []*Constraint{{Gte: MustParse("1.3.0"), Lt: MustParse("1.9.0")}}},
+		{Input: ">=1.2.0 >1.2.0", Expected: []*Constraint{{Gt: MustParse("1.2.0")}}},
+		{Input: ">1", Expected: []*Constraint{{Gte: MustParse("2.0.0")}}},
+		{Input: ">1.2", Expected: []*Constraint{{Gte: MustParse("1.3.0")}}},
+		{Input: "<1.2", Expected: []*Constraint{{Lt: MustParse("1.2.0-0")}}},
+		{Input: "<=1.2", Expected: []*Constraint{{Lt: MustParse("1.3.0-0")}}},
+		{Input: "^1.2.3", Expected: []*Constraint{{Gte: MustParse("1.2.3"), Lt: MustParse("2.0.0-0")}}},
+		{Input: "^0.2.3", Expected: []*Constraint{{Gte: MustParse("0.2.3"), Lt: MustParse("0.3.0-0")}}},
+		{Input: "^0.0.3", Expected: []*Constraint{{Gte: MustParse("0.0.3"), Lt: MustParse("0.0.4-0")}}},
+		{Input: "^0.0", Expected: []*Constraint{{Gte: MustParse("0.0.0"), Lt: MustParse("0.1.0-0")}}},
+		{Input: "^0.x", Expected: []*Constraint{{Gte: MustParse("0.0.0"), Lt: MustParse("1.0.0-0")}}},
+		{Input: "^1.2.x", Expected: []*Constraint{{Gte: MustParse("1.2.0"), Lt: MustParse("2.0.0-0")}}},
+		{Input: "~1.2.3", Expected: []*Constraint{{Gte: MustParse("1.2.3"), Lt: MustParse("1.3.0-0")}}},
+		{Input: "~1.2", Expected: []*Constraint{{Gte: MustParse("1.2.0"), Lt: MustParse("1.3.0-0")}}},
+		{Input: "~1", Expected: []*Constraint{{Gte: MustParse("1.0.0"), Lt: MustParse("2.0.0-0")}}},
+		{Input: "1.x", Expected: []*Constraint{{Gte: MustParse("1.0.0"), Lt: MustParse("2.0.0-0")}}},
+		{Input: "1.2.*", Expected: []*Constraint{{Gte: MustParse("1.2.0"), Lt: MustParse("1.3.0-0")}}},
+		{Input: "1.2 - 1.4", Expected: []*Constraint{{Gte: MustParse("1.2.0"), Lt: MustParse("1.5.0-0")}}},
+		{Input: "1.2.3 - 2.3.4", Expected: []*Constraint{{Gte: MustParse("1.2.3"), Lte: MustParse("2.3.4")}}},
+		{Input: "~1.99999999999999999999", Expected: []*Constraint{{Gte: MustParse("1.99999999999999999999.0"), Lt: MustParse("1.100000000000000000000.0-0")}}},
+		{Input: "^1.2 || >=2.5", Expected: []*Constraint{{Gte: MustParse("1.2.0"), Lt: MustParse("2.0.0-0")}, {Gte: MustParse("2.5.0")}}},
+		{Input: ">=", Err: ErrInvalidConstraint},
+		{Input: "1.2.3.4", Expected: []*Constraint{{Gte: MustParse("1.2.3.4"), Lte: MustParse("1.2.3.4")}}},
+		{Input: "1.2.3.x", Expected: []*Constraint{{Gte: MustParse("1.2.3"), Lt: MustParse("1.2.4-0")}}},
+		{Input: "~1.2.3.4", Expected: []*Constraint{{Gte: MustParse("1.2.3.4"), Lt: MustParse("1.3.0-0")}}},
+		{Input: "^0.0.0.4", Expected: []*Constraint{{Gte: MustParse("0.0.0.4"), Lt: MustParse("0.0.0.5-0")}}},
+		{Input: ">1.2.3.4 <=1.2.3.4.x", Expected: []*Constraint{{Gt: MustParse("1.2.3.4"), Lt: MustParse("1.2.3.5-0")}}},
+		{Input: "1.2.x.4", Err: ErrInvalidConstraint},
+		{Input: "1.x.3", Err: ErrInvalidConstraint},
+		{Input: "1.2-rc.1", Err: ErrInvalidConstraint},
+		{Input: "^1.2 || foo", Err: ErrInvalidConstraint},
+		{Input: ">2.0.0 <1.0.0", Err: ErrContradictoryBounds},
+		{Input: "^1.2 || 2.0 - 1.0", Err: ErrContradictoryBounds},
+		{Input: ">1.0.0 <=1.0.0", Err: ErrEmptyRange},
+	}
+
+	for i, testCase := range testCases {
+		actual, err := ParseConstraint(testCase.Input)
+
+		if testCase.Err != nil {
+			if err == nil {
+				t.Errorf("test %d failed (expected error %s, actual nil)", i, testCase.Err)
+			} else if !errors.Is(err, testCase.Err) {
+				t.Errorf("test %d failed (expected error %s, actual error %s)", i, testCase.Err, err)
+			} else {
+				t.Logf("test %d passed with error %s for %q\n", i, err, testCase.Input)
+			}
+		} else if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+		} else if actual, ok := constraints(actual); !ok || len(actual) != len(testCase.Expected) {
+			t.Errorf("test %d failed (expected %d constraints, actual %v)", i, len(testCase.Expected), actual)
+		} else {
+			ok := true
+			for j, c := range actual {
+				expected := testCase.Expected[j]
+				if !c.Gt.Equal(expected.Gt) || !c.Gte.Equal(expected.Gte) || !c.Lt.Equal(expected.Lt) || !c.Lte.Equal(expected.Lte) {
+					ok = false
+					t.Errorf("test %d failed at position %d (expected %s, actual %s)", i, j, expected, c)
+				}
+			}
+			if ok {
+				t.Logf("test %d passed for %q", i, testCase.Input)
+			}
+		}
+	}
+}
+
+func TestParseConstraint_Match(t *testing.T) {
+	type TestCase struct {
+		Constraint string
+		V          *Version
+		Expected   bool
+	}
+
+	testCases := []TestCase{
+		{Constraint: "!=1.3.4", V: MustParse("1.3.4"), Expected: false},
+		{Constraint: "!=1.3.4", V: MustParse("1.3.5"), Expected: true},
+		{Constraint: ">=1.2 <1.5 !=1.3.4", V: MustParse("1.3.3"), Expected: true},
+		{Constraint: ">=1.2 <1.5 !=1.3.4", V: MustParse("1.3.4"), Expected: false},
+		{Constraint: ">=1.2 <1.5 !=1.3.4", V: MustParse("1.5.0"), Expected: false},
+		{Constraint: "^1.2 != 1.3", V: MustParse("1.3.9"), Expected: false},
+		{Constraint: "^1.2 != 1.3", V: MustParse("1.4.0"), Expected: true},
+		{Constraint: ">=1.2 <1.5 || >=2.0", V: MustParse("1.6.0"), Expected: false},
+		{Constraint: ">=1.2 <1.5 || >=2.0", V: MustParse("2.1.0"), Expected: true},
+		{Constraint: "^1.2.3", V: MustParse("2.0.0-rc.1"), Expected: false},
+		{Constraint: "^1.2.3", V: MustParse("1.9.9"), Expected: true},
+	}
+
+	for i, testCase := range testCases {
+		m, err := ParseConstraint(testCase.Constraint)
+		if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+			continue
+		}
+
+		actual := testCase.V.Match(m)
+		if actual != testCase.Expected {
+			t.Errorf("test %d failed (expected %v, actual %v)", i, testCase.Expected, actual)
+		} else {
+			t.Logf("test %d passed with %v", i, actual)
+		}
+	}
+}
+
+func TestParseConstraintOf(t *testing.T) {
+	m, err := ParseConstraintOf(ParseDebian, ">= 1.0~rc1, << 2.0 || 2.0-1")
+	if err == nil {
+		t.Errorf("expected error for unsupported operator <<, actual %s", m)
+	}
+
+	m, err = ParseConstraintOf(ParseDebian, ">= 1.0~rc1, < 2.0 || 2.0-1")
+	if err != nil {
+		t.Fatalf("expected error nil, actual error %s", err)
+	}
+	u, ok := m.(UnionOf[*Debian])
+	if !ok || len(u) != 2 || fmt.Sprint(m) != ">=1.0~rc1 <2.0 || =2.0-1" {
+		t.Errorf("expected union of two constraints, actual %#v", m)
+	}
+	if s, ok := toSet(m); !ok || s.String() != ">=1.0~rc1 <2.0 || =2.0-1" || !s.Match(MustParseDebian("2.0-1")) || s.Match(MustParseDebian("2.0")) {
+		t.Errorf("expected set equal to %s, actual %s", m, s)
+	}
+
+	if _, err := ParseConstraintOf(ParseDebian, ">2.0 <1.0"); !errors.Is(err, ErrContradictoryBounds) {
+		t.Errorf("expected error %s, actual %v", ErrContradictoryBounds, err)
+	}
+}
+
+func TestConstraint_String(t *testing.T) {
+	type TestCase struct {
+		Input    Matcher
+		Expected string
+	}
+
+	testCases := []TestCase{
+		{Input: (*Constraint)(nil), Expected: "*"},
+		{Input: &Constraint{}, Expected: "*"},
+		{Input: &Constraint{Gte: MustParse("v1.2"), Lt: MustParse("2.0.0")}, Expected: ">=1.2.0 <2.0.0"},
+		{Input: &Constraint{Gt: MustParse("1.2.0-rc.1"), Lte: MustParse("2.0.0+build.5")}, Expected: ">1.2.0-rc.1 <=2.0.0+build.5"},
+		{Input: &Constraint{Gte: MustParse("1.2.3"), Lte: MustParse("1.2.3")}, Expected: "=1.2.3"},
+		{Input: &Constraint{Lt: MustParse("1.0.0")}, Expected: "<1.0.0"},
+		{Input: &Constraint{Gt: MustParse("1.0.0"), Gte: MustParse("0.9.0"), Lt: MustParse("2.0.0")}, Expected: ">1.0.0 <2.0.0 (ignored: >=0.9.0)"},
+		{Input: &Constraint{Gt: MustParse("1.0.0"), Gte: MustParse("0.9.0"), Lt: MustParse("2.0.0"), Lte: MustParse("2.0.0")}, Expected: ">1.0.0 <2.0.0 (ignored: >=0.9.0 <=2.0.0)"},
+		{Input: Union{&Constraint{Gte: MustParse("1.2.0"), Lt: MustParse("1.5.0")}, &Constraint{Gte: MustParse("2.0.0")}}, Expected: ">=1.2.0 <1.5.0 || >=2.0.0"},
+		{Input: Union{}, Expected: "<0.0.0-0"},
+		{Input: Intersection{&Constraint{Gte: MustParse("1.2.0")}, Exclusion{Matcher: &Constraint{Gte: MustParse("1.3.4"), Lte: MustParse("1.3.4")}}}, Expected: ">=1.2.0 !=1.3.4"},
+		{Input: Intersection{&Constraint{}, Exclusion{Matcher: &Constraint{Gte: MustParse("1.3.0"), Lt: MustParse("1.4.0-0")}}}, Expected: "!=1.3"},
+		{Input: Intersection{Union{&Constraint{Lt: MustParse("1.0.0")}, &Constraint{Gt: MustParse("2.0.0")}}, &Constraint{Lt: MustParse("3.0.0")}}, Expected: "(<1.0.0 || >2.0.0) <3.0.0"},
+		{Input: Exclusion{Matcher: &Constraint{Gte: MustParse("1.0.0"), Lt: MustParse("2.0.0-0")}}, Expected: "!=1"},
+		{Input: Exclusion{Matcher: &Constraint{Gte: MustParse("1.0.0"), Lt: MustParse("2.0.0")}}, Expected: "!(>=1.0.0 <2.0.0)"},
+		{Input: Set{}, Expected: "<0.0.0-0"},
+		{Input: NewSet(&Constraint{Gte: MustParse("2.0.0")}, &Constraint{Lt: MustParse("1.0.0")}), Expected: "<1.0.0 || >=2.0.0"},
+	}
+
+	for i, testCase := range testCases {
+		actual := formatMatcher(testCase.Input)
+		if actual != testCase.Expected {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, testCase.Expected, actual)
+		} else {
+			t.Logf("test %d passed with %s", i, actual)
+		}
+	}
+}
+
+func TestConstraint_String_RoundTrip(t *testing.T) {
+	testCases := []string{
+		"*",
+		">=1.2.0 <2.0.0",
+		"=1.2.3",
+		">1.2.0-rc.1 <=2.0.0+build.5",
+		"<0.0.0-0",
+		">=1.2.0 <2.0.0-0 || >=2.5.0",
+		">=1.2.0 !=1.3.4",
+		">=1.2.0 <1.5.0-0 !=1.3",
+		"!=1",
+		"^1.2 || ~2.3.4 || 3.x || 4.1 - 4.3",
+		">= 1.2, < 2",
+		"!=99999999999999999999.1",
+	}
+
+	for i, testCase := range testCases {
+		m, err := ParseConstraint(testCase)
+		if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+			continue
+		}
+
+		str := formatMatcher(m)
+		m2, err := ParseConstraint(str)
+		if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s for %q)", i, err, str)
+		} else if str2 := formatMatcher(m2); str2 != str {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, str, str2)
+		} else if !reflect.DeepEqual(m, m2) {
+			t.Errorf("test %d failed (expected %#v, actual %#v)", i, m, m2)
+		} else {
+			t.Logf("test %d passed with %s", i, str)
+		}
+	}
+}
+
+func TestConstraint_Validate(t *testing.T) {
+	type TestCase struct {
+		Input    *Constraint
+		Expected []error
+	}
+
+	testCases := []TestCase{
+		{},
+		{Input: &Constraint{}},
+		{Input: &Constraint{Gte: MustParse("1.0.0"), Lt: MustParse("2.0.0")}},
+		{Input: &Constraint{Gte: MustParse("1.0.0"), Lte: MustParse("1.0.0")}},
+		{Input: &Constraint{Gt: MustParse("1.0.0"), Gte: MustParse("1.0.0")}, Expected: []error{ErrDuplicateLowerBound}},
+		{Input: &Constraint{Lt: MustParse("1.0.0"), Lte: MustParse("1.0.0")}, Expected: []error{ErrDuplicateUpperBound}},
+		{Input: &Constraint{Gt: MustParse("2.0.0"), Lt: MustParse("1.0.0")}, Expected: []error{ErrContradictoryBounds}},
+		{Input: &Constraint{Gte: MustParse("1.0.0"), Lt: MustParse("1.0.0")}, Expected: []error{ErrEmptyRange}},
+		{Input: &Constraint{Gt: MustParse("1.0.0"), Lte: MustParse("1.0.0")}, Expected: []error{ErrEmptyRange}},
+		{Input: &Constraint{Gt: MustParse("2.0.0"), Gte: MustParse("0.1.0"), Lt: MustParse("1.0.0"), Lte: MustParse("3.0.0")}, Expected: []error{ErrDuplicateLowerBound, ErrDuplicateUpperBound, ErrContradictoryBounds}},
+	}
+
+	for i, testCase := range testCases {
+		err := testCase.Input.Validate()
+
+		if len(testCase.Expected) == 0 {
+			if err != nil {
+				t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+			} else {
+				t.Logf("test %d passed", i)
+			}
+			continue
+		}
+
+		ok := err != nil
+		for _, expected := range testCase.Expected {
+			if !errors.Is(err, expected) {
+				ok = false
+				t.Errorf("test %d failed (expected error %s, actual error %v)", i, expected, err)
+			}
+		}
+		if _, isError := err.(Error); len(testCase.Expected) == 1 && !isError {
+			ok = false
+			t.Errorf("test %d failed (expected error of type Error, actual %T)", i, err)
+		}
+		if ok {
+			t.Logf("test %d passed with error %s", i, err)
+		}
+	}
+
+	if _, err := ParseConstraint(">2.0.0 <1.0.0"); err == nil {
+		t.Error("expected ParseConstraint to return an error")
+	} else if _, ok := err.(Error); !ok {
+		t.Errorf("expected ParseConstraint to return an Error, actual %T", err)
+	}
+}
+
+// constraints flattens a matcher returned by ParseConstraint into a list of simple constraints, if possible.
+func constraints(m Matcher) ([]*Constraint, bool) {
+	switch m := m.(type) {
+	case *Constraint:
+		return []*Constraint{m}, true
+	case Union:
+		cs := []*Constraint{}
+		for _, um := range m {
+			c, ok := um.(*Constraint)
+			if !ok {
+				return nil, false
+			}
+			cs = append(cs, c)
+		}
+		return cs, true
+	}
+	return nil, false
+}
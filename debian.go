@@ -0,0 +1,248 @@
+package version
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Debian is a Debian package version, in the form [epoch:]upstream_version[-debian_revision].
+// See https://www.debian.org/doc/debian-policy/ch-controlfields.html#version
+type Debian struct {
+	Epoch    int    // Epoch, such as 1 in 1:2.30-1ubuntu2. Zero if not given.
+	Upstream string // Upstream version, such as 2.30 in 1:2.30-1ubuntu2.
+	Revision string // Debian revision, such as 1ubuntu2 in 1:2.30-1ubuntu2. Empty if not given.
+
+	Text string // Original version string, if this version was created via the ParseDebian function.
+}
+
+// MustParseDebian parses a Debian version, and panics if it is invalid.
+func MustParseDebian(str string) *Debian {
+	v, err := ParseDebian(str)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// ParseDebian parses a Debian package version, following the rules used by dpkg.
+//
+// The epoch, if given, must be a non-negative integer.
+// The revision follows the last hyphen, so the upstream version may contain hyphens only if there is a revision.
+func ParseDebian(str string) (*Debian, error) {
+	text := strings.TrimSpace(str)
+	if text == "" {
+		return nil, newParseError(ErrInvalidDebian, KindEmpty, str, SectionMajor, 0)
+	}
+	if i := strings.IndexAny(text, " \t\n"); i > -1 {
+		return nil, newError(ErrInvalidDebian, str)
+	}
+
+	v := &Debian{Upstream: text, Text: str}
+	if i := strings.IndexByte(text, ':'); i > -1 {
+		epoch, err := strconv.Atoi(text[:i])
+		if err != nil || epoch < 0 || !isNumeric(text[:i]) {
+			return nil, newParseError(ErrInvalidDebian, KindNonNumeric, str, SectionMajor, 0)
+		}
+		v.Epoch = epoch
+		v.Upstream = text[i+1:]
+	}
+	if i := strings.LastIndexByte(v.Upstream, '-'); i > -1 {
+		v.Revision = v.Upstream[i+1:]
+		v.Upstream = v.Upstream[:i]
+		if v.Revision == "" {
+			return nil, newParseError(ErrInvalidDebian, KindEmpty, str, SectionExtension, len(str))
+		}
+	}
+	if v.Upstream == "" {
+		return nil, newError(ErrInvalidDebian, str)
+	}
+
+	for _, c := range []byte(v.Upstream) {
+		if !isAlphanumeric(c) && strings.IndexByte(".+~-:", c) < 0 {
+			return nil, newError(ErrInvalidDebian, str)
+		}
+	}
+	for _, c := range []byte(v.Revision) {
+		if !isAlphanumeric(c) && strings.IndexByte(".+~", c) < 0 {
+			return nil, newError(ErrInvalidDebian, str)
+		}
+	}
+
+	return v, nil
+}
+
+// ParseDebianRange parses comma-separated version relations, as used in the Depends field of a Debian package, such as (>= 1.2-1), (<< 2.0).
+//
+// The operators <<, <=, =, >= and >> are supported, as well as the obsolete < and >, which mean <= and >=.
+// The relations are validated as described by Constraint.Validate, so relations that contradict each other, such as (>> 2.0), (<< 1.0), are rejected.
+func ParseDebianRange(str string) (*ConstraintOf[*Debian], error) {
+	b := &interval[*Debian]{}
+	for _, clause := range strings.Split(str, ",") {
+		clause = strings.TrimSpace(clause)
+		clause = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(clause, "("), ")"))
+
+		op := ""
+		for _, o := range []string{"<<", "<=", ">=", ">>", "=", "<", ">"} {
+			if strings.HasPrefix(clause, o) {
+				op = o
+				break
+			}
+		}
+		v, err := ParseDebian(strings.TrimSpace(clause[len(op):]))
+		if op == "" || err != nil {
+			return nil, newError(ErrInvalidDebianRange, str)
+		}
+
+		switch op {
+		case "<<":
+			op = "<"
+		case ">>":
+			op = ">"
+		case "<":
+			op = "<="
+		case ">":
+			op = ">="
+		}
+		b.Apply(op, v)
+	}
+
+	c := b.Constraint()
+	if err := c.validate(str); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Compare this version (a) with another version (b), following the rules used by dpkg.
+// This function returns -1 if a is less than b, 1 if a is greater than b, or 0 if a is equal to b.
+//
+// Epochs are compared numerically, then the upstream versions and revisions are each compared in alternating non-digit and digit parts.
+// In non-digit parts, a tilde sorts before anything, even the end of the part, and letters sort before other characters.
+// So 1.0~rc1 is less than 1.0, which is less than 1.0a, which is less than 1.0+b.
+//
+// See https://www.debian.org/doc/debian-policy/ch-controlfields.html#version
+func (a *Debian) Compare(b *Debian) int {
+	if a == nil {
+		if b == nil {
+			return 0
+		}
+		return -1
+	} else if b == nil {
+		return 1
+	}
+
+	if a.Epoch != b.Epoch {
+		return sign(a.Epoch - b.Epoch)
+	}
+	if cmp := compareDebianPart(a.Upstream, b.Upstream); cmp != 0 {
+		return cmp
+	}
+	return compareDebianPart(a.Revision, b.Revision)
+}
+
+// Equal determines whether this version (a) is equal to another version (b).
+func (a *Debian) Equal(b *Debian) bool {
+	return a.Compare(b) == 0
+}
+
+// Less determines whether this version (a) is less than another version (b).
+func (a *Debian) Less(b *Debian) bool {
+	return a.Compare(b) < 0
+}
+
+// Match tests the version against a constraint, such as a ConstraintOf.
+// If the constraint is nil, this function returns true.
+func (v *Debian) Match(m MatcherOf[*Debian]) bool {
+	if v == nil {
+		return false
+	}
+	if m == nil {
+		return true
+	}
+	return m.Match(v)
+}
+
+// Canonical returns the canonical form of the version, omitting a zero epoch.
+func (v *Debian) Canonical() string {
+	if v == nil {
+		return ""
+	}
+
+	str := v.Upstream
+	if v.Epoch != 0 || strings.IndexByte(str, ':') > -1 {
+		str = strconv.Itoa(v.Epoch) + ":" + str
+	}
+	if v.Revision != "" {
+		str += "-" + v.Revision
+	}
+	return str
+}
+
+func (v *Debian) String() string {
+	if v == nil {
+		return ""
+	}
+
+	if v.Text != "" {
+		return v.Text
+	}
+
+	return v.Canonical()
+}
+
+// compareDebianPart compares two upstream versions or revisions, as in the verrevcmp function of dpkg.
+func compareDebianPart(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && !isDigit(a[i]) || j < len(b) && !isDigit(b[j]) {
+			ac, bc := debianOrder(a, i), debianOrder(b, j)
+			if ac != bc {
+				return sign(ac - bc)
+			}
+			i++
+			j++
+		}
+
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+
+		diff := 0
+		for i < len(a) && isDigit(a[i]) && j < len(b) && isDigit(b[j]) {
+			if diff == 0 {
+				diff = int(a[i]) - int(b[j])
+			}
+			i++
+			j++
+		}
+		if i < len(a) && isDigit(a[i]) {
+			return 1
+		}
+		if j < len(b) && isDigit(b[j]) {
+			return -1
+		}
+		if diff != 0 {
+			return sign(diff)
+		}
+	}
+	return 0
+}
+
+// debianOrder returns the weight of the character at offset i in a non-digit part of a Debian version.
+// The end of the string and digits have weight zero, a tilde is lower and other characters are higher, with letters before non-letters.
+func debianOrder(str string, i int) int {
+	if i >= len(str) || isDigit(str[i]) {
+		return 0
+	}
+	c := str[i]
+	switch {
+	case c == '~':
+		return -1
+	case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+		return int(c)
+	}
+	return int(c) + 256
+}
@@ -0,0 +1,176 @@
+package version
+
+import (
+	"errors"
+	"sort"
+	"testing"
+)
+
+func TestParseDebian(t *testing.T) {
+	type TestCase struct {
+		Input    string
+		Epoch    int
+		Upstream string
+		Revision string
+		Err      error
+	}
+
+	testCases := []TestCase{
+		{Input: "1:2.30-1ubuntu2", Epoch: 1, Upstream: "2.30", Revision: "1ubuntu2"},
+		{Input: "2.0~rc1-3", Upstream: "2.0~rc1", Revision: "3"},
+		{Input: "1.2.3", Upstream: "1.2.3"},
+		{Input: "0:1.2.3", Upstream: "1.2.3"},
+		{Input: "1.0-beta-2", Upstream: "1.0-beta", Revision: "2"},
+		{Input: "2:1:0.9+dfsg-1", Epoch: 2, Upstream: "1:0.9+dfsg", Revision: "1"},
+		{Input: "1.0+git20231010.abc~1-0.1", Upstream: "1.0+git20231010.abc~1", Revision: "0.1"},
+		{Input: "", Err: ErrInvalidDebian},
+		{Input: "a:1.0", Err: ErrInvalidDebian},
+		{Input: ":1.0", Err: ErrInvalidDebian},
+		{Input: "1.0-", Err: ErrInvalidDebian},
+		{Input: "-1", Err: ErrInvalidDebian},
+		{Input: "1.0_1", Err: ErrInvalidDebian},
+		{Input: "1.0-1:2", Err: ErrInvalidDebian},
+		{Input: "1.0 1", Err: ErrInvalidDebian},
+	}
+
+	for i, testCase := range testCases {
+		actual, err := ParseDebian(testCase.Input)
+
+		if testCase.Err != nil {
+			if !errors.Is(err, testCase.Err) {
+				t.Errorf("test %d failed (expected error %s, actual %v)", i, testCase.Err, err)
+			} else {
+				t.Logf("test %d passed with error %s for %q\n", i, err, testCase.Input)
+			}
+		} else if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+		} else if actual.Epoch != testCase.Epoch || actual.Upstream != testCase.Upstream || actual.Revision != testCase.Revision {
+			t.Errorf("test %d failed (expected %d:%s-%s, actual %d:%s-%s)", i, testCase.Epoch, testCase.Upstream, testCase.Revision, actual.Epoch, actual.Upstream, actual.Revision)
+		} else {
+			t.Logf("test %d passed with %s", i, actual)
+		}
+	}
+
+	if str := MustParseDebian("0:1.2-1").Canonical(); str != "1.2-1" {
+		t.Errorf("expected semantic string 1.2-1, actual %s", str)
+	}
+}
+
+func TestDebian_Compare(t *testing.T) {
+	ordered := []string{
+		"1.0~~",
+		"1.0~~a",
+		"1.0~",
+		"1.0~rc1",
+		"1.0~rc2",
+		"1.0",
+		"1.0-1",
+		"1.0-1ubuntu1",
+		"1.0-1.1",
+		"1.0-2",
+		"1.0a",
+		"1.0a.1",
+		"1.0+b1",
+		"1.0.1",
+		"1.2",
+		"1.10",
+		"2.0~rc1-3",
+		"2.0",
+		"1:0.1",
+		"1:2.30-1ubuntu2",
+		"2:0",
+	}
+
+	for i := 1; i < len(ordered); i++ {
+		a := MustParseDebian(ordered[i-1])
+		b := MustParseDebian(ordered[i])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("test %d failed (expected %s < %s)", i, a, b)
+		}
+	}
+
+	equal := [][2]string{{"1.0", "0:1.0"}, {"1.01", "1.1"}, {"1.0-0", "1.0-00"}}
+	for _, pair := range equal {
+		if cmp := MustParseDebian(pair[0]).Compare(MustParseDebian(pair[1])); cmp != 0 {
+			t.Errorf("expected %s == %s, actual %d", pair[0], pair[1], cmp)
+		}
+	}
+
+	list := ListOf[*Debian]{}
+	for i := len(ordered) - 1; i >= 0; i-- {
+		list = append(list, MustParseDebian(ordered[i]))
+	}
+	sort.Sort(list)
+	for i, v := range list {
+		if v.Text != ordered[i] {
+			t.Errorf("sort failed at position %d (expected %s, actual %s)", i, ordered[i], v)
+		}
+	}
+}
+
+func TestParseDebianRange(t *testing.T) {
+	type TestCase struct {
+		Input    string
+		Expected string
+		Match    []string
+		NoMatch  []string
+		Err      error
+	}
+
+	testCases := []TestCase{
+		{Input: "(>= 1.0), (<< 2.0)", Expected: ">=1.0 <2.0", Match: []string{"1.0", "1.5-1", "2.0~rc1"}, NoMatch: []string{"1.0~rc1", "2.0", "1:0.1"}},
+		{Input: ">> 1.0-1", Expected: ">1.0-1", Match: []string{"1.0-2", "1.0.1"}, NoMatch: []string{"1.0-1", "1.0"}},
+		{Input: "(= 1:2.30-1ubuntu2)", Expected: "=1:2.30-1ubuntu2", Match: []string{"1:2.30-1ubuntu2"}, NoMatch: []string{"2.30-1ubuntu2"}},
+		{Input: "(<= 2.0), (<< 1.5)", Expected: "<1.5", Match: []string{"1.4"}, NoMatch: []string{"1.5"}},
+		{Input: "(< 2.0)", Expected: "<=2.0", Match: []string{"2.0"}, NoMatch: []string{"2.0-1"}},
+		{Input: "(> 2.0)", Expected: ">=2.0", Match: []string{"2.0"}, NoMatch: []string{"2.0~rc1"}},
+		{Input: "", Err: ErrInvalidDebianRange},
+		{Input: "1.0", Err: ErrInvalidDebianRange},
+		{Input: "(>= 1.0), ", Err: ErrInvalidDebianRange},
+		{Input: "(!= 1.0)", Err: ErrInvalidDebianRange},
+		{Input: ">> 2.0, << 1.0", Err: ErrContradictoryBounds},
+		{Input: "(>> 1.0), (<= 1.0)", Err: ErrEmptyRange},
+	}
+
+	for i, testCase := range testCases {
+		actual, err := ParseDebianRange(testCase.Input)
+
+		if testCase.Err != nil {
+			if !errors.Is(err, testCase.Err) {
+				t.Errorf("test %d failed (expected error %s, actual %v)", i, testCase.Err, err)
+			} else {
+				t.Logf("test %d passed with error %s for %q\n", i, err, testCase.Input)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+			continue
+		} else if actual.String() != testCase.Expected {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, testCase.Expected, actual)
+			continue
+		}
+
+		ok := true
+		for _, str := range testCase.Match {
+			if !MustParseDebian(str).Match(actual) {
+				ok = false
+				t.Errorf("test %d failed (expected %s to match %s)", i, str, actual)
+			}
+		}
+		for _, str := range testCase.NoMatch {
+			if MustParseDebian(str).Match(actual) {
+				ok = false
+				t.Errorf("test %d failed (expected %s not to match %s)", i, str, actual)
+			}
+		}
+		if ok {
+			t.Logf("test %d passed with %s", i, actual)
+		}
+	}
+
+	list := ListOf[*Debian]{MustParseDebian("0.9"), MustParseDebian("1.0~rc1"), MustParseDebian("1.0-1"), MustParseDebian("2.0")}
+	r, _ := ParseDebianRange("(>= 1.0~), (<< 2.0~)")
+	if matched := list.Match(r); len(matched) != 2 || matched[1].String() != "1.0-1" {
+		t.Errorf("list match failed (actual %v)", matched)
+	}
+}
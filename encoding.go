@@ -0,0 +1,119 @@
+package version
+
+import "fmt"
+
+// MarshalText encodes the version as its string form.
+// https://pkg.go.dev/encoding#TextMarshaler
+//
+// This method has a value receiver so that Version values, as well as pointers, are encoded as strings in formats such as JSON.
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText decodes a version string using Parse.
+// https://pkg.go.dev/encoding#TextUnmarshaler
+func (v *Version) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*v = *parsed
+	return nil
+}
+
+// MarshalText encodes the constraint in canonical form.
+// https://pkg.go.dev/encoding#TextMarshaler
+//
+// Unlike String, any Gte or Lte bound that is ignored by Match is omitted, so that the result can always be decoded.
+func (c ConstraintOf[V]) MarshalText() ([]byte, error) {
+	return []byte(c.interval().String()), nil
+}
+
+// UnmarshalText decodes a constraint string using ParseConstraint.
+// https://pkg.go.dev/encoding#TextUnmarshaler
+//
+// Only a single range can be decoded into a Constraint.
+// Use a Set to decode unions and exclusions.
+//
+// ParseConstraint only reads versions of type *Version, so an ErrDecodeType error is returned for any other type.
+func (c *ConstraintOf[V]) UnmarshalText(text []byte) error {
+	m, err := parseConstraintOf[V](string(text))
+	if err != nil {
+		return err
+	}
+
+	parsed, ok := m.(*ConstraintOf[V])
+	if !ok {
+		return newError(ErrMultipleRanges, string(text))
+	}
+	*c = *parsed
+	return nil
+}
+
+// MarshalText encodes the set in canonical form.
+// https://pkg.go.dev/encoding#TextMarshaler
+func (s SetOf[V]) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a constraint string using ParseConstraint, normalizing the result into a set.
+// https://pkg.go.dev/encoding#TextUnmarshaler
+//
+// Any constraint syntax can be decoded into a Set, including unions and exclusions.
+// As for Constraint, an ErrDecodeType error is returned for any type of version other than *Version.
+func (s *SetOf[V]) UnmarshalText(text []byte) error {
+	m, err := parseConstraintOf[V](string(text))
+	if err != nil {
+		return err
+	}
+
+	parsed, _ := toSet(m)
+	*s = parsed
+	return nil
+}
+
+// parseConstraintOf parses a constraint using ParseConstraint, if V is *Version.
+func parseConstraintOf[V Comparable[V]](str string) (MatcherOf[V], error) {
+	parse, ok := any(ParseConstraint).(func(string) (MatcherOf[V], error))
+	if !ok {
+		var v V
+		return nil, newError(ErrDecodeType, fmt.Sprintf("%T", v))
+	}
+	return parse(str)
+}
+
+// MarshalText encodes the frozen version as its semantic string form.
+// https://pkg.go.dev/encoding#TextMarshaler
+//
+// This allows a Frozen version to be used as a map key in formats such as JSON.
+func (f Frozen) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText decodes a version string using Parse.
+// https://pkg.go.dev/encoding#TextUnmarshaler
+func (f *Frozen) UnmarshalText(text []byte) error {
+	v, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*f = v.Freeze()
+	return nil
+}
+
+// MarshalText encodes the module version in canonical form.
+// https://pkg.go.dev/encoding#TextMarshaler
+func (m ModuleVersion) MarshalText() ([]byte, error) {
+	return []byte(m.Canonical()), nil
+}
+
+// UnmarshalText decodes a Go module version using ParseModule.
+// https://pkg.go.dev/encoding#TextUnmarshaler
+func (m *ModuleVersion) UnmarshalText(text []byte) error {
+	parsed, err := ParseModule(string(text))
+	if err != nil {
+		return err
+	}
+	*m = *parsed
+	return nil
+}
@@ -0,0 +1,104 @@
+package version
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestVersion_JSON(t *testing.T) {
+	type Config struct {
+		Current   Version
+		Versions  []*Version
+		Optional  *Version
+		Supported *Constraint
+		Allowed   Set
+	}
+
+	input := `{"Current":"v1.2.3","Versions":["1.0.0","v2.0.0-rc.1+build.5"],"Optional":null,"Supported":"^1.2","Allowed":"^1.2 || >=2.0.0 !=2.1.0"}`
+	expected := `{"Current":"v1.2.3","Versions":["1.0.0","v2.0.0-rc.1+build.5"],"Optional":null,"Supported":">=1.2.0 <2.0.0-0","Allowed":">=1.2.0 <2.0.0-0 || >=2.0.0 <2.1.0 || >2.1.0"}`
+
+	config := Config{}
+	if err := json.Unmarshal([]byte(input), &config); err != nil {
+		t.Fatalf("unmarshal failed with error %s", err)
+	}
+
+	if !config.Current.Equal(MustParse("1.2.3")) || len(config.Versions) != 2 || !config.Versions[1].Equal(MustParse("2.0.0-rc.1")) || config.Optional != nil {
+		t.Errorf("unmarshal failed (actual %+v)", config)
+	}
+	if !MustParse("1.9.0").Match(config.Supported) || MustParse("2.0.0").Match(config.Supported) {
+		t.Errorf("unmarshal failed (actual constraint %s)", config.Supported)
+	}
+	if !MustParse("2.2.0").Match(config.Allowed) || MustParse("2.1.0").Match(config.Allowed) {
+		t.Errorf("unmarshal failed (actual set %s)", config.Allowed)
+	}
+
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(config); err != nil {
+		t.Fatalf("marshal failed with error %s", err)
+	}
+	if actual := strings.TrimSpace(buf.String()); actual != expected {
+		t.Errorf("marshal failed (expected %s, actual %s)", expected, actual)
+	}
+}
+
+func TestSet_MarshalText(t *testing.T) {
+	for i, s := range []Set{{}, {{}}, NewSet(&Constraint{Gte: MustParse("1.0.0")})} {
+		text, err := s.MarshalText()
+		if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+			continue
+		}
+
+		actual := Set{}
+		if err := actual.UnmarshalText(text); err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+		} else if !setsEqual(actual, s) {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, s, actual)
+		} else {
+			t.Logf("test %d passed with %s", i, text)
+		}
+	}
+}
+
+func TestVersion_UnmarshalText(t *testing.T) {
+	type TestCase struct {
+		Input string
+		Into  interface{ UnmarshalText([]byte) error }
+		Err   error
+	}
+
+	testCases := []TestCase{
+		{Input: "v1.2.3", Into: &Version{}},
+		{Input: "invalid version", Into: &Version{}, Err: ErrInvalidVersion},
+		{Input: ">=1.2.0 <2.0.0", Into: &Constraint{}},
+		{Input: "^1.2 || ^2.0", Into: &Constraint{}, Err: ErrMultipleRanges},
+		{Input: "^1.2 !=1.3.4", Into: &Constraint{}, Err: ErrMultipleRanges},
+		{Input: ">2.0.0 <1.0.0", Into: &Constraint{}, Err: ErrContradictoryBounds},
+		{Input: "^1.2 || ^2.0", Into: &Set{}},
+		{Input: "foo", Into: &Set{}, Err: ErrInvalidConstraint},
+		{Input: ">=1.0", Into: &ConstraintOf[*Debian]{}, Err: ErrDecodeType},
+	}
+
+	for i, testCase := range testCases {
+		err := testCase.Into.UnmarshalText([]byte(testCase.Input))
+
+		if testCase.Err != nil {
+			if err == nil {
+				t.Errorf("test %d failed (expected error %s, actual nil)", i, testCase.Err)
+			} else if !errors.Is(err, testCase.Err) {
+				t.Errorf("test %d failed (expected error %s, actual error %s)", i, testCase.Err, err)
+			} else {
+				t.Logf("test %d passed with error %s for %q\n", i, err, testCase.Input)
+			}
+		} else if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+		} else {
+			t.Logf("test %d passed with %v", i, testCase.Into)
+		}
+	}
+}
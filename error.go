@@ -1,21 +1,130 @@
 package version
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
 
 // Version error.
 var (
 	ErrInvalidVersion = Error{Message: "invalid version %q"}
+
+	ErrEmpty            = Error{Message: "empty component in version %q", Kind: KindEmpty}
+	ErrInvalidCharacter = Error{Message: "invalid character in version %q", Kind: KindInvalidCharacter}
+	ErrLeadingZero      = Error{Message: "leading zero in numeric identifier in version %q", Kind: KindLeadingZero}
+	ErrNonNumeric       = Error{Message: "non-numeric component in version %q", Kind: KindNonNumeric}
+	ErrOverflow         = Error{Message: "number too large in version %q", Kind: KindOverflow}
+
+	ErrEmptyIdentifier  = Error{Message: "empty identifier in version %q", Kind: KindEmpty}
+	ErrExtraComponent   = Error{Message: "too many numeric components in version %q"}
+	ErrMissingComponent = Error{Message: "missing minor or patch number in version %q"}
+	ErrPrefix           = Error{Message: "unexpected prefix in version %q", Kind: KindInvalidCharacter}
+
+	ErrDecodeType      = Error{Message: "cannot decode constraint on versions of type %s"}
+	ErrInvalidBinary   = Error{Message: "invalid binary version %q"}
+	ErrInvalidSortable = Error{Message: "invalid sortable version %q"}
+	ErrNotSortable     = Error{Message: "version %q cannot be encoded in sortable form"}
+	ErrScanNull        = Error{Message: "cannot scan NULL into %s"}
+	ErrScanType        = Error{Message: "cannot scan value of type %s"}
+
+	ErrIncompatible         = Error{Message: "+incompatible requires major version 2 or more in Go module version %q"}
+	ErrInvalidModuleVersion = Error{Message: "invalid Go module version %q"}
+	ErrInvalidPseudoVersion = Error{Message: "pseudo-version %q has no valid base version"}
+	ErrModulePath           = Error{Message: "version does not match major version suffix of module %q"}
+
+	ErrInvalidDebian      = Error{Message: "invalid Debian version %q"}
+	ErrInvalidDebianRange = Error{Message: "invalid Debian version relation %q"}
+
+	ErrInvalidRPM = Error{Message: "invalid RPM version %q"}
+
+	ErrCalVerNext          = Error{Message: "no next version after %q for the given date"}
+	ErrCalVerYear          = Error{Message: "year %q cannot be written in the CalVer format"}
+	ErrInvalidCalVer       = Error{Message: "invalid CalVer version %q"}
+	ErrInvalidCalVerFormat = Error{Message: "invalid CalVer format %q"}
+
+	ErrInvalidMaven      = Error{Message: "invalid Maven version %q"}
+	ErrInvalidMavenRange = Error{Message: "invalid Maven version range %q"}
+
+	ErrInvalidPEP440          = Error{Message: "invalid PEP 440 version %q"}
+	ErrInvalidPEP440Specifier = Error{Message: "invalid PEP 440 specifier %q"}
+
+	ErrInvalidConstraint = Error{Message: "invalid constraint %q"}
+	ErrMultipleRanges    = Error{Message: "constraint %q is not a single range"}
+
+	ErrContradictoryBounds = Error{Message: "lower bound is greater than upper bound in constraint %q"}
+	ErrDuplicateLowerBound = Error{Message: "both Gt and Gte are set in constraint %q"}
+	ErrDuplicateUpperBound = Error{Message: "both Lt and Lte are set in constraint %q"}
+	ErrEmptyRange          = Error{Message: "bounds exclude the only version in constraint %q"}
+)
+
+// Kind is a machine-readable cause of a version error.
+type Kind int
+
+// Kinds of version error.
+// Each kind has a sentinel error, such as ErrLeadingZero, which matches any error of that kind using errors.Is.
+const (
+	KindNone Kind = iota
+	KindEmpty
+	KindNonNumeric
+	KindLeadingZero
+	KindOverflow
+	KindInvalidCharacter
+)
+
+// Section is a part of a version string in which an error was found.
+type Section int
+
+// Sections of a version string.
+const (
+	SectionNone Section = iota
+	SectionMajor
+	SectionMinor
+	SectionPatch
+	SectionSegment
+	SectionExtension
 )
 
 // Error represents a version error.
 type Error struct {
 	Message string
 	Version string
+
+	Kind    Kind    // Cause of the error, if known.
+	Section Section // Section of the version in which the error was found, if known.
+	Offset  int     // Byte offset in Version at which the error was found. Only meaningful if Section is set.
 }
 
 // Error retrieves the message of a REST API error.
+// If the position of the error is known, its section and offset are included.
 func (e Error) Error() string {
-	return fmt.Sprintf(e.Message, e.Version)
+	msg := fmt.Sprintf(e.Message, e.Version)
+	if e.Section == SectionNone {
+		return msg
+	}
+
+	if e.Message == ErrInvalidVersion.Message && e.Kind != KindNone {
+		msg += ": " + e.Kind.String()
+	}
+	return fmt.Sprintf("%s (%s, offset %d)", msg, e.Section, e.Offset)
+}
+
+// Diagnostic returns the error message followed by the version and a caret marking the position of the error, for display in a terminal.
+// For example:
+//
+//	invalid version "1..2": empty component (minor number, offset 2)
+//	  1..2
+//	    ^
+//
+// If the position of the error is not known, only the error message is returned.
+func (e Error) Diagnostic() string {
+	if e.Section == SectionNone {
+		return e.Error()
+	}
+
+	offset := min(max(e.Offset, 0), len(e.Version))
+	col := utf8.RuneCountInString(e.Version[:offset])
+	return fmt.Sprintf("%s\n  %s\n  %s^", e.Error(), e.Version, strings.Repeat(" ", col))
 }
 
 // Is determines whether the Error is an instance of the target.
@@ -29,9 +138,93 @@ func (e Error) Is(target error) bool {
 	return false
 }
 
+// Unwrap returns the sentinel error for the Kind of the error, if it is not already that error.
+// This allows, for example, an ErrInvalidVersion caused by a leading zero to match ErrLeadingZero.
+// https://pkg.go.dev/errors#Unwrap
+func (e Error) Unwrap() error {
+	if cause := e.Kind.sentinel(); cause != nil && cause.Message != e.Message {
+		return *cause
+	}
+	return nil
+}
+
+func (k Kind) String() string {
+	switch k {
+	case KindEmpty:
+		return "empty component"
+	case KindNonNumeric:
+		return "non-numeric component"
+	case KindLeadingZero:
+		return "leading zero"
+	case KindOverflow:
+		return "number too large"
+	case KindInvalidCharacter:
+		return "invalid character"
+	}
+	return "unknown error"
+}
+
+// sentinel returns the sentinel error for a kind of error.
+func (k Kind) sentinel() *Error {
+	switch k {
+	case KindEmpty:
+		return &ErrEmpty
+	case KindNonNumeric:
+		return &ErrNonNumeric
+	case KindLeadingZero:
+		return &ErrLeadingZero
+	case KindOverflow:
+		return &ErrOverflow
+	case KindInvalidCharacter:
+		return &ErrInvalidCharacter
+	}
+	return nil
+}
+
+func (s Section) String() string {
+	switch s {
+	case SectionMajor:
+		return "major number"
+	case SectionMinor:
+		return "minor number"
+	case SectionPatch:
+		return "patch number"
+	case SectionSegment:
+		return "numeric segment"
+	case SectionExtension:
+		return "extension"
+	}
+	return "unknown section"
+}
+
 func invalid(version string) Error {
+	return newError(ErrInvalidVersion, version)
+}
+
+func newError(err Error, version string) Error {
 	return Error{
-		Message: ErrInvalidVersion.Message,
+		Message: err.Message,
 		Version: version,
+		Kind:    err.Kind,
+	}
+}
+
+// newParseError creates an error at a known position in a version string.
+// If kind is KindNone, the kind of err is used.
+func newParseError(err Error, kind Kind, version string, section Section, offset int) Error {
+	e := newError(err, version)
+	if kind != KindNone {
+		e.Kind = kind
+	}
+	e.Section = section
+	e.Offset = offset
+	return e
+}
+
+// numberSection returns the Section of a numeric component of a version, such as SectionMinor for sectionMinor.
+func numberSection(section int) Section {
+	if section > sectionPatch {
+		return SectionSegment
 	}
+	return Section(section + 1)
 }
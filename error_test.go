@@ -0,0 +1,82 @@
+package version
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestError_Diagnostic(t *testing.T) {
+	type TestCase struct {
+		Input    string
+		Parse    func(string) (*Version, error)
+		Err      error
+		Section  Section
+		Offset   int
+		Expected string
+	}
+
+	testCases := []TestCase{
+		{Input: "", Parse: Parse, Err: ErrEmpty, Section: SectionMajor, Offset: 0, Expected: "invalid version \"\": empty component (major number, offset 0)\n  \n  ^"},
+		{Input: "1..2", Parse: Parse, Err: ErrEmpty, Section: SectionMinor, Offset: 2, Expected: "invalid version \"1..2\": empty component (minor number, offset 2)\n  1..2\n    ^"},
+		{Input: "v-any", Parse: Parse, Err: ErrNonNumeric, Section: SectionMajor, Offset: 1, Expected: "invalid version \"v-any\": non-numeric component (major number, offset 1)\n  v-any\n   ^"},
+		{Input: "1.2.3.4..5", Parse: Parse, Err: ErrEmpty, Section: SectionSegment, Offset: 8, Expected: "invalid version \"1.2.3.4..5\": empty component (numeric segment, offset 8)\n  1.2.3.4..5\n          ^"},
+		{Input: "1.02.3", Parse: ParseStrict, Err: ErrLeadingZero, Section: SectionMinor, Offset: 2, Expected: "leading zero in numeric identifier in version \"1.02.3\" (minor number, offset 2)\n  1.02.3\n    ^"},
+		{Input: "1.2.3-rc..1", Parse: ParseStrict, Err: ErrEmpty, Section: SectionExtension, Offset: 9, Expected: "empty identifier in version \"1.2.3-rc..1\" (extension, offset 9)\n  1.2.3-rc..1\n           ^"},
+		{Input: "1.2.3-αlpha.β", Parse: ParseStrict, Err: ErrInvalidCharacter, Section: SectionExtension, Offset: 6, Expected: "invalid character in version \"1.2.3-αlpha.β\" (extension, offset 6)\n  1.2.3-αlpha.β\n        ^"},
+		{Input: "1.2.3+b.β", Parse: ParseStrict, Err: ErrInvalidCharacter, Section: SectionExtension, Offset: 8, Expected: "invalid character in version \"1.2.3+b.β\" (extension, offset 8)\n  1.2.3+b.β\n          ^"},
+		{Input: "1.2x.3", Parse: ParseStrict, Err: ErrInvalidCharacter, Section: SectionMinor, Offset: 3, Expected: "invalid character in version \"1.2x.3\" (minor number, offset 3)\n  1.2x.3\n     ^"},
+		{Input: "v1.2.3", Parse: ParseStrict, Err: ErrPrefix, Section: SectionMajor, Offset: 0, Expected: "unexpected prefix in version \"v1.2.3\" (major number, offset 0)\n  v1.2.3\n  ^"},
+		{Input: "1.2", Parse: ParseStrict, Err: ErrMissingComponent, Section: SectionPatch, Offset: 3, Expected: "missing minor or patch number in version \"1.2\" (patch number, offset 3)\n  1.2\n     ^"},
+		{Input: "1.2.3.4", Parse: ParseStrict, Err: ErrExtraComponent, Section: SectionSegment, Offset: 6, Expected: "too many numeric components in version \"1.2.3.4\" (numeric segment, offset 6)\n  1.2.3.4\n        ^"},
+	}
+
+	for i, testCase := range testCases {
+		_, err := testCase.Parse(testCase.Input)
+
+		actual := Error{}
+		if !errors.As(err, &actual) {
+			t.Errorf("test %d failed (expected error %s, actual %v)", i, testCase.Err, err)
+		} else if !errors.Is(err, testCase.Err) {
+			t.Errorf("test %d failed (expected error %s, actual error %s)", i, testCase.Err, err)
+		} else if actual.Section != testCase.Section || actual.Offset != testCase.Offset {
+			t.Errorf("test %d failed (expected %s at offset %d, actual %s at offset %d)", i, testCase.Section, testCase.Offset, actual.Section, actual.Offset)
+		} else if actual.Diagnostic() != testCase.Expected {
+			t.Errorf("test %d failed (expected %q, actual %q)", i, testCase.Expected, actual.Diagnostic())
+		} else {
+			t.Logf("test %d passed with\n%s", i, actual.Diagnostic())
+		}
+	}
+}
+
+func TestError_Is(t *testing.T) {
+	type TestCase struct {
+		Err      error
+		Target   error
+		Expected bool
+	}
+
+	_, nonNumeric := Parse("1.x")
+	_, leadingZero := ParseStrict("01.2.3")
+	_, overflow := MustParse("1.2.3-123456789012345678901234567890123456789012345678901234567890").SortableString()
+
+	testCases := []TestCase{
+		{Err: nonNumeric, Target: ErrInvalidVersion, Expected: true},
+		{Err: nonNumeric, Target: ErrNonNumeric, Expected: true},
+		{Err: nonNumeric, Target: ErrEmpty, Expected: false},
+		{Err: leadingZero, Target: ErrLeadingZero, Expected: true},
+		{Err: leadingZero, Target: ErrInvalidVersion, Expected: false},
+		{Err: overflow, Target: ErrNotSortable, Expected: true},
+		{Err: overflow, Target: ErrOverflow, Expected: true},
+		{Err: overflow, Target: ErrInvalidVersion, Expected: false},
+		{Err: newError(ErrMultipleRanges, "1.x || 2.x"), Target: ErrInvalidCharacter, Expected: false},
+	}
+
+	for i, testCase := range testCases {
+		actual := errors.Is(testCase.Err, testCase.Target)
+		if actual != testCase.Expected {
+			t.Errorf("test %d failed (expected %t, actual %t for %s)", i, testCase.Expected, actual, testCase.Err)
+		} else {
+			t.Logf("test %d passed with %t for %s", i, actual, testCase.Err)
+		}
+	}
+}
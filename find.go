@@ -0,0 +1,192 @@
+package version
+
+import "strings"
+
+// Found is a version found in text, with its position.
+type Found struct {
+	Version *Version
+	Start   int // Byte offset of the start of the version in the text.
+	End     int // Byte offset of the end of the version in the text, so that text[Start:End] is the version string.
+}
+
+// Finder finds versions embedded in text, such as the output of a command or a filename.
+//
+// A version is a run of numbers separated by periods, such as 1.25.2, optionally prefixed with v.
+// It may follow a letter, as in go1.21.3, but not a digit or period, so that part of a longer number is never found.
+type Finder struct {
+	// Minimum number of numeric components, such as 3 to skip versions like 1.2.
+	// If zero, at least 2 are required, so that bare numbers such as years are not found.
+	MinSegments int
+
+	// Maximum number of numeric components, such as 3 to skip IP addresses like 192.168.0.1.
+	// A run of numbers with more components is skipped entirely.
+	// If zero, there is no maximum.
+	MaxSegments int
+
+	// Whether to include pre-release and build metadata suffixes, such as -rc.1+build.5.
+	// Suffixes follow the syntax of Semantic Versioning, but stop before a platform word such as linux or amd64, and before a file extension such as .tar.gz.
+	// So in app-2.4.1-rc.1-linux-amd64.tar.gz, the version found is 2.4.1-rc.1.
+	PreRelease bool
+
+	// Whether to skip numbers that look like dates, with a year from 1900 to 2099 followed by a month and optionally a day, such as 2022.03.15.
+	// Calendar versions such as 2024.10 are also skipped.
+	SkipDates bool
+}
+
+// findPlatforms are words that name an operating system or architecture, which end a version suffix.
+var findPlatforms = map[string]bool{
+	"aarch64": true, "amd64": true, "android": true, "arm": true, "arm64": true, "armhf": true, "armv6": true, "armv7": true,
+	"darwin": true, "freebsd": true, "i386": true, "i686": true, "ios": true, "linux": true, "macos": true, "netbsd": true,
+	"noarch": true, "openbsd": true, "osx": true, "ppc64le": true, "riscv64": true, "s390x": true, "universal": true,
+	"win32": true, "win64": true, "windows": true, "x64": true, "x86": true,
+}
+
+// findExtensions are file extensions, which end a version suffix when they follow a period.
+var findExtensions = map[string]bool{
+	"7z": true, "apk": true, "appimage": true, "asc": true, "bin": true, "bz2": true, "deb": true, "dll": true, "dmg": true,
+	"exe": true, "gz": true, "img": true, "iso": true, "jar": true, "json": true, "msi": true, "pkg": true, "rpm": true,
+	"sha256": true, "sig": true, "so": true, "tar": true, "tgz": true, "txt": true, "war": true, "whl": true, "xz": true,
+	"yaml": true, "yml": true, "zip": true, "zst": true,
+}
+
+// Find returns the first version in text, using the default settings of a Finder.
+func Find(text string) (Found, bool) {
+	return Finder{}.Find(text)
+}
+
+// FindAll returns all versions in text, using the default settings of a Finder.
+func FindAll(text string) []Found {
+	return Finder{}.FindAll(text)
+}
+
+// Find returns the first version in text.
+func (f Finder) Find(text string) (Found, bool) {
+	return f.find(text, 0)
+}
+
+// FindAll returns all versions in text, in order.
+func (f Finder) FindAll(text string) []Found {
+	found := []Found{}
+	for i := 0; i < len(text); {
+		r, ok := f.find(text, i)
+		if !ok {
+			break
+		}
+		found = append(found, r)
+		i = r.End
+	}
+	return found
+}
+
+// find returns the first version in text starting at or after offset i.
+func (f Finder) find(text string, i int) (Found, bool) {
+	minSegments := f.MinSegments
+	if minSegments <= 0 {
+		minSegments = 2
+	}
+
+	for i < len(text) {
+		if !isDigit(text[i]) || i > 0 && (isDigit(text[i-1]) || text[i-1] == '.') {
+			i++
+			continue
+		}
+
+		start := i
+		if i > 0 && (text[i-1] == 'v' || text[i-1] == 'V') && (i == 1 || !isAlphanumeric(text[i-2])) {
+			start--
+		}
+
+		end := i
+		segments := 0
+		for {
+			for end < len(text) && isDigit(text[end]) {
+				end++
+			}
+			segments++
+			if end+1 < len(text) && text[end] == '.' && isDigit(text[end+1]) {
+				end++
+				continue
+			}
+			break
+		}
+
+		if segments < minSegments || f.MaxSegments > 0 && segments > f.MaxSegments || f.SkipDates && isDate(text[i:end]) {
+			i = end
+			continue
+		}
+
+		if f.PreRelease {
+			if end+1 < len(text) && text[end] == '-' {
+				end = scanSuffix(text, end+1, end)
+			}
+			if end+1 < len(text) && text[end] == '+' {
+				end = scanSuffix(text, end+1, end)
+			}
+		}
+
+		v, err := Parse(text[start:end])
+		if err != nil {
+			i = end
+			continue
+		}
+		return Found{Version: v, Start: start, End: end}, true
+	}
+
+	return Found{}, false
+}
+
+// isAlphanumeric determines whether a byte is an ASCII letter or digit.
+func isAlphanumeric(c byte) bool {
+	return isDigit(c) || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// isDigit determines whether a byte is an ASCII digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isDate determines whether dot-separated numbers look like a date, with a four-digit year from 1900 to 2099, a month, and optionally a day.
+func isDate(str string) bool {
+	parts := strings.Split(str, ".")
+	if len(parts) > 3 || len(parts[0]) != 4 || parts[0][:2] != "19" && parts[0][:2] != "20" {
+		return false
+	}
+
+	limits := []int{0, 12, 31}
+	for i := 1; i < len(parts); i++ {
+		if len(parts[i]) > 2 {
+			return false
+		}
+		n := 0
+		for _, c := range []byte(parts[i]) {
+			n = n*10 + int(c-'0')
+		}
+		if n < 1 || n > limits[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// scanSuffix returns the end of the identifiers starting at offset i in text, separated by periods or hyphens.
+// Scanning stops before a platform word, such as linux, or a file extension following a period, such as .tar.
+// If there are no identifiers, the given fallback offset is returned.
+func scanSuffix(text string, i, fallback int) int {
+	end := fallback
+	for i < len(text) {
+		j := i
+		for j < len(text) && isAlphanumeric(text[j]) {
+			j++
+		}
+		word := strings.ToLower(text[i:j])
+		if j == i || findPlatforms[word] || text[i-1] == '.' && findExtensions[word] {
+			break
+		}
+		end = j
+		if j >= len(text) || text[j] != '.' && text[j] != '-' {
+			break
+		}
+		i = j + 1
+	}
+	return end
+}
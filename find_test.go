@@ -0,0 +1,67 @@
+package version
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFinder_FindAll(t *testing.T) {
+	type TestCase struct {
+		Finder   Finder
+		Input    string
+		Expected []string
+	}
+
+	testCases := []TestCase{
+		{Input: "go version go1.21.3 linux/amd64", Expected: []string{"1.21.3"}},
+		{Input: "nginx version: nginx/1.25.2", Expected: []string{"1.25.2"}},
+		{Input: "OpenSSL 3.0.2 15 Mar 2022", Expected: []string{"3.0.2"}},
+		{Input: "app-2.4.1-linux-amd64.tar.gz", Expected: []string{"2.4.1"}},
+		{Input: "upgrade from v1.2.3 to V2.0.0.", Expected: []string{"v1.2.3", "V2.0.0"}},
+		{Input: "dev1.2 and 1.2.3.4", Expected: []string{"1.2", "1.2.3.4"}},
+		{Input: "released 2022-03-15, build 42", Expected: []string{}},
+		{Input: "1.2.3-rc.1+build.5", Expected: []string{"1.2.3"}},
+		{Finder: Finder{PreRelease: true}, Input: "1.2.3-rc.1+build.5, then 1.2.4-.", Expected: []string{"1.2.3-rc.1+build.5", "1.2.4"}},
+		{Finder: Finder{PreRelease: true}, Input: "app-2.4.1-linux-amd64.tar.gz", Expected: []string{"2.4.1"}},
+		{Finder: Finder{PreRelease: true}, Input: "app-2.4.1-rc.1-Linux-x86_64.tar.gz", Expected: []string{"2.4.1-rc.1"}},
+		{Finder: Finder{PreRelease: true}, Input: "tool-1.0.0-beta.2.zip and tool-1.0.0+build.7.sig", Expected: []string{"1.0.0-beta.2", "1.0.0+build.7"}},
+		{Finder: Finder{MaxSegments: 3}, Input: "host 192.168.0.1 runs 1.2.3", Expected: []string{"1.2.3"}},
+		{Input: "released 2022.03.15 on 192.168.0.1", Expected: []string{"2022.03.15", "192.168.0.1"}},
+		{Finder: Finder{MaxSegments: 3, SkipDates: true}, Input: "released 2022.03.15 on 192.168.0.1", Expected: []string{}},
+		{Finder: Finder{SkipDates: true}, Input: "2024.10 and 1999.12.31 but not 2024.13.1 or 2100.01", Expected: []string{"2024.13.1", "2100.01"}},
+		{Finder: Finder{MinSegments: 3}, Input: "python 3.12 and 3.12.1", Expected: []string{"3.12.1"}},
+		{Finder: Finder{MinSegments: 1}, Input: "v2 or 3", Expected: []string{"v2", "3"}},
+		{Input: "", Expected: []string{}},
+	}
+
+	for i, testCase := range testCases {
+		found := testCase.Finder.FindAll(testCase.Input)
+
+		actual := []string{}
+		ok := true
+		for _, f := range found {
+			str := testCase.Input[f.Start:f.End]
+			actual = append(actual, str)
+			if f.Version.String() != str {
+				ok = false
+			}
+		}
+
+		if !ok || !reflect.DeepEqual(actual, testCase.Expected) {
+			t.Errorf("test %d failed (expected %q, actual %q)", i, testCase.Expected, actual)
+		} else {
+			t.Logf("test %d passed with %q", i, actual)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	found, ok := Find("nginx/1.25.2 (Ubuntu)")
+	if !ok || found.Start != 6 || found.End != 12 || !found.Version.Equal(MustParse("1.25.2")) {
+		t.Errorf("find failed (actual %+v)", found)
+	}
+
+	if found, ok := Find("no version here"); ok {
+		t.Errorf("find failed (expected nothing, actual %+v)", found)
+	}
+}
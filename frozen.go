@@ -0,0 +1,122 @@
+package version
+
+import "strings"
+
+// Frozen is an immutable version.
+// Unlike *Version, it holds no pointers or slices, so it can be copied freely, compared with ==, and used as a map key.
+//
+// Two Frozen versions are equal with == if they have the same SemanticString.
+// The original Text of the version is not kept, so v1.2.3 and 1.2.3 freeze to the same value.
+// Use Compare to order versions by precedence, which also ignores build metadata.
+//
+// The zero value represents a nil version.
+type Frozen struct {
+	major, minor, patch int
+
+	str string // Semantic string form of the version.
+	key string // Sort key of the version, or empty if it cannot be encoded.
+}
+
+// Freeze returns an immutable copy of the version.
+// Later changes to the version do not affect the copy.
+func (v *Version) Freeze() Frozen {
+	if v == nil {
+		return Frozen{}
+	}
+
+	numbers := []string{}
+	for _, n := range v.Numbers() {
+		numbers = append(numbers, n.String())
+	}
+	preRelease, build := v.identifiers()
+
+	return Frozen{
+		major: v.Major,
+		minor: v.Minor,
+		patch: v.Patch,
+		str:   strings.Join(numbers, ".") + formatExtension(preRelease, build),
+		key:   string(v.SortKey()),
+	}
+}
+
+// Build returns a copy of the build metadata identifiers of the version.
+func (f Frozen) Build() []Identifier {
+	return f.Version().Build
+}
+
+// Compare this version (a) with another version (b).
+// This function returns -1 if a is less than b, 1 if a is greater than b, or 0 if a is equal to b.
+//
+// Precedence is determined as described by Version.Compare.
+func (a Frozen) Compare(b Frozen) int {
+	if a.key != "" && b.key != "" {
+		return strings.Compare(a.key, b.key)
+	}
+	return a.Version().Compare(b.Version())
+}
+
+// Equal determines whether this version (a) has the same precedence as another version (b).
+// Unlike ==, this ignores build metadata.
+func (a Frozen) Equal(b Frozen) bool {
+	return a.Compare(b) == 0
+}
+
+// IsZero determines whether the value represents a nil version.
+func (f Frozen) IsZero() bool {
+	return f.str == ""
+}
+
+// Less determines whether this version (a) has lower precedence than another version (b).
+func (a Frozen) Less(b Frozen) bool {
+	return a.Compare(b) < 0
+}
+
+// Major returns the major version number.
+// As with Version.Major, a number too large for an int is given as math.MaxInt.
+func (f Frozen) Major() int {
+	return f.major
+}
+
+// Match tests the version against a constraint.
+func (f Frozen) Match(m Matcher) bool {
+	return f.Version().Match(m)
+}
+
+// Minor returns the minor version number.
+func (f Frozen) Minor() int {
+	return f.minor
+}
+
+// Numbers returns a copy of the exact numbers of the version.
+func (f Frozen) Numbers() []Number {
+	return f.Version().Numbers()
+}
+
+// Patch returns the patch version number.
+func (f Frozen) Patch() int {
+	return f.patch
+}
+
+// PreRelease returns a copy of the pre-release identifiers of the version.
+func (f Frozen) PreRelease() []Identifier {
+	return f.Version().PreRelease
+}
+
+func (f Frozen) String() string {
+	return f.str
+}
+
+// Version returns a new, mutable copy of the version.
+// If the value represents a nil version, nil is returned.
+func (f Frozen) Version() *Version {
+	if f.str == "" {
+		return nil
+	}
+
+	v, err := Parse(f.str)
+	if err != nil {
+		return nil
+	}
+	v.Text = ""
+	return v
+}
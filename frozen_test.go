@@ -0,0 +1,130 @@
+package version
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestVersion_Freeze(t *testing.T) {
+	type TestCase struct {
+		Input    *Version
+		Expected string
+	}
+
+	testCases := []TestCase{
+		{Input: MustParse("v1.2.3"), Expected: "1.2.3"},
+		{Input: MustParse("1.2.0a"), Expected: "1.2.0-a"},
+		{Input: MustParse("1.0.0-rc.1+build.5"), Expected: "1.0.0-rc.1+build.5"},
+		{Input: MustParse("10.0.19041.3636"), Expected: "10.0.19041.3636"},
+		{Input: MustParse("1.2.99999999999999999999"), Expected: "1.2.99999999999999999999"},
+		{Input: &Version{Major: 1, PreRelease: []Identifier{"beta"}}, Expected: "1.0.0-beta"},
+		{Input: nil, Expected: ""},
+	}
+
+	for i, testCase := range testCases {
+		actual := testCase.Input.Freeze()
+		if actual.String() != testCase.Expected {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, testCase.Expected, actual)
+		} else if actual.IsZero() != (testCase.Input == nil) {
+			t.Errorf("test %d failed (expected zero %t, actual %t)", i, testCase.Input == nil, actual.IsZero())
+		} else if thawed := actual.Version(); thawed.Compare(testCase.Input) != 0 || !reflect.DeepEqual(thawed.Numbers(), testCase.Input.Numbers()) {
+			t.Errorf("test %d failed (expected %v, actual %v)", i, testCase.Input, thawed)
+		} else {
+			t.Logf("test %d passed with %s", i, actual)
+		}
+	}
+}
+
+func TestFrozen_Aliasing(t *testing.T) {
+	v := MustParse("1.2.3-rc.1")
+	f := v.Freeze()
+
+	v.Major = 2
+	v.PreRelease[0] = "beta"
+
+	if f.Major() != 1 || f.String() != "1.2.3-rc.1" {
+		t.Errorf("frozen version changed with original (actual %s)", f)
+	}
+
+	ids := f.PreRelease()
+	ids[0] = "alpha"
+	if f.PreRelease()[0] != "rc" {
+		t.Errorf("frozen version changed with its identifiers (actual %s)", f)
+	}
+
+	thawed := f.Version()
+	thawed.Patch = 9
+	if f.Patch() != 3 {
+		t.Errorf("frozen version changed with its copy (actual %s)", f)
+	}
+}
+
+func TestFrozen_Map(t *testing.T) {
+	inputs := []string{"1.2.3", "v1.2.3", "1.2.03", "1.2.3+build.5", "1.2.3-rc.1", "1.2.4", "1.2.3.4"}
+	expected := []string{"1.2.3", "1.2.3+build.5", "1.2.3-rc.1", "1.2.4", "1.2.3.4"}
+
+	seen := map[Frozen]int{}
+	for _, str := range inputs {
+		seen[MustParse(str).Freeze()]++
+	}
+
+	if len(seen) != len(expected) {
+		t.Errorf("expected %d unique versions, actual %d (%v)", len(expected), len(seen), seen)
+	}
+	for _, str := range expected {
+		if _, ok := seen[MustParse(str).Freeze()]; !ok {
+			t.Errorf("expected %s in map", str)
+		}
+	}
+	if seen[MustParse("1.2.3").Freeze()] != 3 {
+		t.Errorf("expected 1.2.3 to be counted 3 times, actual %d", seen[MustParse("1.2.3").Freeze()])
+	}
+
+	data, err := json.Marshal(seen)
+	if err != nil {
+		t.Fatalf("marshal failed with error %s", err)
+	}
+	decoded := map[Frozen]int{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal failed with error %s", err)
+	}
+	if !reflect.DeepEqual(seen, decoded) {
+		t.Errorf("round trip failed (expected %v, actual %v)", seen, decoded)
+	}
+}
+
+func TestFrozen_Compare(t *testing.T) {
+	type TestCase struct {
+		A        Frozen
+		B        Frozen
+		Expected int
+	}
+
+	testCases := []TestCase{
+		{A: MustParse("1.2.3").Freeze(), B: MustParse("1.2.4").Freeze(), Expected: -1},
+		{A: MustParse("1.2.3").Freeze(), B: MustParse("1.2.3-rc.1").Freeze(), Expected: 1},
+		{A: MustParse("1.2.3+build.1").Freeze(), B: MustParse("1.2.3+build.2").Freeze(), Expected: 0},
+		{A: MustParse("1.2.3").Freeze(), B: MustParse("1.2.3.0").Freeze(), Expected: 0},
+		{A: MustParse("1.2.100000000000000000000").Freeze(), B: MustParse("1.2.99999999999999999999").Freeze(), Expected: 1},
+		{A: MustParse("1.0.0-a\x00").Freeze(), B: MustParse("1.0.0-a").Freeze(), Expected: 1},
+		{A: MustParse("0.0.0").Freeze(), B: Frozen{}, Expected: 1},
+		{A: Frozen{}, B: Frozen{}, Expected: 0},
+	}
+
+	for i, testCase := range testCases {
+		actual := testCase.A.Compare(testCase.B)
+		if actual != testCase.Expected {
+			t.Errorf("test %d failed (expected %d, actual %d)", i, testCase.Expected, actual)
+		} else {
+			t.Logf("test %d passed with %d", i, actual)
+		}
+	}
+
+	list := []Frozen{MustParse("2.0.0").Freeze(), MustParse("1.0.0").Freeze(), MustParse("1.0.0-rc.1").Freeze()}
+	sort.Slice(list, func(i, j int) bool { return list[i].Less(list[j]) })
+	if list[0].String() != "1.0.0-rc.1" || list[2].String() != "2.0.0" {
+		t.Errorf("sort failed (actual %v)", list)
+	}
+}
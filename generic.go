@@ -0,0 +1,38 @@
+package version
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Comparable is implemented by any type of version that can be ordered against other versions of the same type, such as *Version and *PEP440.
+type Comparable[V any] interface {
+	// Compare this version (a) with another version (b).
+	// This function returns -1 if a is less than b, 1 if a is greater than b, or 0 if a is equal to b.
+	Compare(b V) int
+}
+
+// MatcherOf is implemented by any constraint on a type of version.
+// Matcher is the MatcherOf for *Version.
+type MatcherOf[V any] interface {
+	Match(v V) bool
+}
+
+// formatVersion returns the canonical form of a version of any type.
+// The Canonical or SemanticString method of the version is used if it has one, or otherwise its String method.
+func formatVersion[V any](v V) string {
+	switch v := any(v).(type) {
+	case interface{ Canonical() string }:
+		return v.Canonical()
+	case interface{ SemanticString() string }:
+		return v.SemanticString()
+	}
+	return fmt.Sprint(v)
+}
+
+// isNil determines whether a version is nil, or the zero value of a version type that is not a pointer.
+// A nil version never matches a constraint, and a nil bound of a constraint is unset.
+func isNil[V any](v V) bool {
+	rv := reflect.ValueOf(any(v))
+	return !rv.IsValid() || rv.IsZero()
+}
@@ -0,0 +1,148 @@
+package version
+
+import "strings"
+
+// Identifier is a single dot-separated pre-release or build metadata identifier, such as "rc" or "1" in 1.0.0-rc.1.
+type Identifier string
+
+// Compare this identifier (a) with another identifier (b) according to the pre-release precedence rules of Semantic Versioning 2.0.0.
+// This function returns -1 if a is less than b, 1 if a is greater than b, or 0 if a is equal to b.
+//
+// Numeric identifiers are compared numerically and always have lower precedence than alphanumeric identifiers, which are compared lexically in ASCII sort order.
+func (a Identifier) Compare(b Identifier) int {
+	an := a.Numeric()
+	bn := b.Numeric()
+
+	if an && bn {
+		return compareDigits(string(a), string(b))
+	} else if an {
+		return -1
+	} else if bn {
+		return 1
+	}
+	return strings.Compare(string(a), string(b))
+}
+
+// Numeric determines whether the identifier consists only of decimal digits.
+func (id Identifier) Numeric() bool {
+	return isNumeric(string(id))
+}
+
+func (id Identifier) String() string {
+	return string(id)
+}
+
+// compareIdentifiers compares two lists of pre-release identifiers (a and b) according to Semantic Versioning 2.0.0.
+// This function returns -1 if a has lower precedence than b, 1 if a has higher precedence than b, or 0 if they are equal.
+//
+// An empty list indicates a normal version, which has higher precedence than any pre-release.
+func compareIdentifiers(a, b []Identifier) int {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	} else if len(a) == 0 {
+		return 1
+	} else if len(b) == 0 {
+		return -1
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if cmp := a[i].Compare(b[i]); cmp != 0 {
+			return cmp
+		}
+	}
+
+	if len(a) < len(b) {
+		return -1
+	} else if len(a) > len(b) {
+		return 1
+	}
+	return 0
+}
+
+// compareDigits numerically compares two strings of decimal digits of any length.
+func compareDigits(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+
+	if len(a) < len(b) {
+		return -1
+	} else if len(a) > len(b) {
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// formatExtension joins pre-release and build metadata identifiers into a version extension, such as -rc.1+build.5.
+func formatExtension(preRelease, build []Identifier) string {
+	str := ""
+	if len(preRelease) > 0 {
+		str += "-" + joinIdentifiers(preRelease)
+	}
+	if len(build) > 0 {
+		str += "+" + joinIdentifiers(build)
+	}
+	return str
+}
+
+// isNumeric determines whether a string consists only of decimal digits.
+func isNumeric(str string) bool {
+	if len(str) == 0 {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		if str[i] < '0' || str[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func joinIdentifiers(ids []Identifier) string {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = string(id)
+	}
+	return strings.Join(strs, ".")
+}
+
+// splitExtension separates a version extension into pre-release and build metadata identifiers.
+// The pre-release part may omit its leading hyphen, as in the lenient form 1.2.0a.
+func splitExtension(ext string) (preRelease, build []Identifier) {
+	pre, meta := strings.TrimPrefix(ext, "-"), ""
+	if i := strings.IndexByte(pre, '+'); i > -1 {
+		pre, meta = pre[:i], pre[i+1:]
+	}
+	if pre == "" && meta == "" {
+		return nil, nil
+	}
+
+	// Both lists share a single allocation
+	ids := make([]Identifier, 0, strings.Count(pre, ".")+strings.Count(meta, ".")+2)
+	if pre != "" {
+		ids = appendIdentifiers(ids, pre)
+		preRelease = ids[:len(ids):len(ids)]
+	}
+	if meta != "" {
+		build = appendIdentifiers(ids[len(ids):], meta)
+	}
+	return
+}
+
+func splitIdentifiers(str string) []Identifier {
+	if str == "" {
+		return nil
+	}
+	return appendIdentifiers(make([]Identifier, 0, strings.Count(str, ".")+1), str)
+}
+
+// appendIdentifiers appends the dot-separated identifiers in a string to a list.
+func appendIdentifiers(ids []Identifier, str string) []Identifier {
+	for {
+		i := strings.IndexByte(str, '.')
+		if i < 0 {
+			return append(ids, Identifier(str))
+		}
+		ids = append(ids, Identifier(str[:i]))
+		str = str[i+1:]
+	}
+}
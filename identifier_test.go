@@ -0,0 +1,34 @@
+package version
+
+import "testing"
+
+func TestIdentifier_Compare(t *testing.T) {
+	type TestCase struct {
+		A        Identifier
+		B        Identifier
+		Expected int
+	}
+
+	testCases := []TestCase{
+		{A: "1", B: "1", Expected: 0},
+		{A: "1", B: "01", Expected: 0},
+		{A: "2", B: "11", Expected: -1},
+		{A: "11", B: "2", Expected: 1},
+		{A: "99999999999999999999", B: "100000000000000000000", Expected: -1},
+		{A: "1", B: "alpha", Expected: -1},
+		{A: "alpha", B: "1", Expected: 1},
+		{A: "alpha", B: "beta", Expected: -1},
+		{A: "rc", B: "RC", Expected: 1},
+		{A: "alpha", B: "alpha", Expected: 0},
+		{A: "1a", B: "1", Expected: 1},
+	}
+
+	for i, testCase := range testCases {
+		actual := testCase.A.Compare(testCase.B)
+		if actual != testCase.Expected {
+			t.Errorf("test %d failed (expected %d, actual %d)", i, testCase.Expected, actual)
+		} else {
+			t.Logf("test %d passed with %d", i, actual)
+		}
+	}
+}
@@ -1,14 +1,24 @@
 package version
 
+// ListOf is a slice of versions of any comparable type that implements sort.Interface.
+// Versions are sorted in order of precedence, as determined by their Compare method.
+// To sort versions according to a Scheme instead, use SortOf.
+type ListOf[V Comparable[V]] []V
+
 // List is a slice of versions that implements sort.Interface.
-type List []*Version
+// Versions are sorted in order of precedence, as determined by Version.Compare.
+type List = ListOf[*Version]
 
-// Match tests versions against a constraint and returns a new List of matching versions only.
-func (list List) Match(c *Constraint) List {
-	filtered := List{}
+// Match tests versions against a constraint and returns a new list of matching versions only.
+// If the constraint is nil, all versions match. Nil versions never match.
+func (list ListOf[V]) Match(m MatcherOf[V]) ListOf[V] {
+	filtered := ListOf[V]{}
 
 	for _, v := range list {
-		if v.Match(c) {
+		if isNil(v) {
+			continue
+		}
+		if m == nil || m.Match(v) {
 			filtered = append(filtered, v)
 		}
 	}
@@ -16,15 +26,15 @@ func (list List) Match(c *Constraint) List {
 	return filtered
 }
 
-func (list List) Len() int {
+func (list ListOf[V]) Len() int {
 	return len(list)
 }
 
-func (list List) Less(i, j int) bool {
-	return list[i].Less(list[j])
+func (list ListOf[V]) Less(i, j int) bool {
+	return list[i].Compare(list[j]) < 0
 }
 
-func (list List) Swap(i, j int) {
+func (list ListOf[V]) Swap(i, j int) {
 	a := list[i]
 	b := list[j]
 	list[i] = b
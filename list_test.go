@@ -24,6 +24,14 @@ func TestListSort(t *testing.T) {
 			Input:    List{MustParse("2.0.4"), MustParse("1.2.4"), MustParse("1.2.3"), MustParse("1.3.1")},
 			Expected: List{MustParse("1.2.3"), MustParse("1.2.4"), MustParse("1.3.1"), MustParse("2.0.4")},
 		},
+		{
+			Input:    List{MustParse("1.0.0"), MustParse("1.0.0-rc.1"), MustParse("1.0.0-beta.11"), MustParse("1.0.0-beta.2"), MustParse("1.0.0-beta"), MustParse("1.0.0-alpha.beta"), MustParse("1.0.0-alpha.1"), MustParse("1.0.0-alpha")},
+			Expected: List{MustParse("1.0.0-alpha"), MustParse("1.0.0-alpha.1"), MustParse("1.0.0-alpha.beta"), MustParse("1.0.0-beta"), MustParse("1.0.0-beta.2"), MustParse("1.0.0-beta.11"), MustParse("1.0.0-rc.1"), MustParse("1.0.0")},
+		},
+		{
+			Input:    List{MustParse("1.2.3.10"), MustParse("1.2.4"), MustParse("1.2.3.9"), MustParse("1.2.3"), MustParse("1.2.3.9.1")},
+			Expected: List{MustParse("1.2.3"), MustParse("1.2.3.9"), MustParse("1.2.3.9.1"), MustParse("1.2.3.10"), MustParse("1.2.4")},
+		},
 	}
 
 	for i, testCase := range testCases {
@@ -51,7 +59,7 @@ func TestListSort(t *testing.T) {
 func TestList_Match(t *testing.T) {
 	type TestCase struct {
 		Input      List
-		Constraint *Constraint
+		Constraint Matcher
 		Expected   List
 	}
 
@@ -75,12 +83,30 @@ func TestList_Match(t *testing.T) {
 			Constraint: &Constraint{Gte: MustParse("2.0.2")},
 			Expected:   List{MustParse("2.0.2"), MustParse("3.4.5")},
 		},
+		{
+			Input:      List{MustParse("1.0.0"), MustParse("1.1.0"), MustParse("2.0.2"), MustParse("3.4.5")},
+			Constraint: Union{&Constraint{Lt: MustParse("1.1.0")}, Intersection{&Constraint{Gte: MustParse("2.0.0")}, Exclusion{Matcher: &Constraint{Gte: MustParse("2.0.2"), Lte: MustParse("2.0.2")}}}},
+			Expected:   List{MustParse("1.0.0"), MustParse("3.4.5")},
+		},
+		{
+			Input:    List{nil, MustParse("1.0.0"), nil},
+			Expected: List{MustParse("1.0.0")},
+		},
+		{
+			Input:      List{nil, MustParse("1.0.0")},
+			Constraint: &Constraint{Gte: MustParse("1.0.0")},
+			Expected:   List{MustParse("1.0.0")},
+		},
 	}
 
 	for i, testCase := range testCases {
 		actual := testCase.Input.Match(testCase.Constraint)
 
 		ok := true
+		if len(actual) != len(testCase.Expected) {
+			t.Errorf("test %d failed (expected %s, got %s)", i, testCase.Expected, actual)
+			continue
+		}
 		for j, v := range actual {
 			expected := testCase.Expected[j]
 			cmp := v.Compare(expected)
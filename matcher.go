@@ -0,0 +1,196 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Matcher is implemented by any constraint that a version can be tested against.
+// *Constraint, Union, Intersection and Exclusion all implement Matcher.
+type Matcher = MatcherOf[*Version]
+
+// Union matches a version that matches any of its constraints.
+// An empty Union matches no versions.
+type Union = UnionOf[*Version]
+
+// Intersection matches a version that matches all of its constraints.
+// An empty Intersection matches all versions.
+type Intersection = IntersectionOf[*Version]
+
+// Exclusion matches a version that does not match its constraint, such as !=1.3.4.
+type Exclusion = ExclusionOf[*Version]
+
+// UnionOf matches a version of any type that matches any of its constraints.
+// A nil constraint matches any version.
+type UnionOf[V any] []MatcherOf[V]
+
+// IntersectionOf matches a version of any type that matches all of its constraints.
+// A nil constraint matches any version.
+type IntersectionOf[V any] []MatcherOf[V]
+
+// ExclusionOf matches a version of any type that does not match its constraint.
+type ExclusionOf[V any] struct {
+	Matcher MatcherOf[V]
+}
+
+// none is the canonical form of a constraint that matches no versions.
+const none = "<0.0.0-0"
+
+// lowest is the lowest *Version, since 0 is the lowest pre-release identifier.
+var lowest = MustParse("0.0.0-0")
+
+// Match tests a version against each constraint in the union.
+func (u UnionOf[V]) Match(v V) bool {
+	if isNil(v) {
+		return false
+	}
+
+	for _, m := range u {
+		if m == nil || m.Match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match tests a version against each constraint in the intersection.
+func (in IntersectionOf[V]) Match(v V) bool {
+	if isNil(v) {
+		return false
+	}
+
+	for _, m := range in {
+		if m != nil && !m.Match(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Match tests a version against the excluded constraint.
+// If no constraint is set, no versions are excluded.
+func (e ExclusionOf[V]) Match(v V) bool {
+	if isNil(v) {
+		return false
+	}
+
+	if e.Matcher == nil {
+		return true
+	}
+	return !e.Matcher.Match(v)
+}
+
+// String returns the canonical form of the union, such as >=1.2.0 <1.5.0 || >=2.0.0.
+// An empty union is written as <0.0.0-0 for *Version, or !=* for other types of version, which matches no versions.
+func (u UnionOf[V]) String() string {
+	if len(u) == 0 {
+		return noneOf[V]()
+	}
+
+	strs := make([]string, len(u))
+	for i, m := range u {
+		strs[i] = formatMatcher(m)
+	}
+	return strings.Join(strs, " || ")
+}
+
+// String returns the canonical form of the intersection, such as >=1.2.0 <1.5.0 !=1.3.4.
+// An intersection of unions cannot be expressed in range syntax, so its unions are written in parentheses.
+func (in IntersectionOf[V]) String() string {
+	strs := []string{}
+	for _, m := range in {
+		str := formatMatcher(m)
+		if u, ok := m.(UnionOf[V]); ok && len(u) > 1 {
+			str = "(" + str + ")"
+		}
+		if str != "*" {
+			strs = append(strs, str)
+		}
+	}
+
+	if len(strs) == 0 {
+		return "*"
+	}
+	return strings.Join(strs, " ")
+}
+
+// String returns the canonical form of the exclusion, such as !=1.3.4 or !=1.3 for an excluded x-range.
+// Exclusions of other constraints cannot be expressed in range syntax, so they are written as !(constraint).
+func (e ExclusionOf[V]) String() string {
+	if e.Matcher == nil {
+		return "*"
+	}
+
+	if c, ok := e.Matcher.(interface{ exclusion() (string, bool) }); ok {
+		if str, ok := c.exclusion(); ok {
+			return str
+		}
+	}
+	return "!(" + formatMatcher(e.Matcher) + ")"
+}
+
+// exclusion returns the != form of the constraint, as described by interval.exclusion.
+func (c *ConstraintOf[V]) exclusion() (string, bool) {
+	return c.interval().exclusion()
+}
+
+// exclusion returns the != form of an interval that matches a single version, or an x-range of *Version, if possible.
+func (b *interval[V]) exclusion() (string, bool) {
+	if b.Lower == nil && b.Upper == nil {
+		return "!=*", true
+	}
+	if b.Lower == nil || b.Upper == nil || !b.Lower.Inclusive {
+		return "", false
+	}
+
+	if b.Upper.Inclusive {
+		if b.Lower.Version.Compare(b.Upper.Version) == 0 {
+			return "!=" + formatVersion(b.Lower.Version), true
+		}
+		return "", false
+	}
+
+	lower, ok := any(b.Lower.Version).(*Version)
+	upper, _ := any(b.Upper.Version).(*Version)
+	if !ok || len(lower.preRelease()) > 0 || !lower.number(sectionPatch).isZero() || len(lower.Segments) > 0 {
+		return "", false
+	}
+	p := partial{Numbers: []Number{lower.number(sectionMajor), lower.number(sectionMinor)}}
+	if upper.Equal(p.Next(2, "0")) {
+		return fmt.Sprintf("!=%s.%s", p.Numbers[sectionMajor], p.Numbers[sectionMinor]), true
+	}
+	if p.Numbers[sectionMinor].isZero() && upper.Equal(p.Next(1, "0")) {
+		return fmt.Sprintf("!=%s", p.Numbers[sectionMajor]), true
+	}
+	return "", false
+}
+
+// formatMatcher returns the string form of any matcher.
+func formatMatcher[V any](m MatcherOf[V]) string {
+	if m == nil {
+		return "*"
+	}
+	if s, ok := m.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprint(m)
+}
+
+// noneOf returns the canonical form of a constraint that matches no versions of a type.
+// Other types of version than *Version may not have a lowest version, so !=* is used instead of <0.0.0-0.
+func noneOf[V any]() string {
+	var v V
+	if _, ok := any(v).(*Version); ok {
+		return none
+	}
+	return "!=*"
+}
+
+// lowestOf returns the lowest version of a type, if it has one.
+func lowestOf[V any]() (V, bool) {
+	var v V
+	if _, ok := any(v).(*Version); ok {
+		return any(lowest).(V), true
+	}
+	return v, false
+}
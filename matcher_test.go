@@ -0,0 +1,60 @@
+package version
+
+import "testing"
+
+func TestMatcher_Match(t *testing.T) {
+	type TestCase struct {
+		V        *Version
+		M        Matcher
+		Expected bool
+	}
+
+	a := &Constraint{Gte: MustParse("1.2.0"), Lt: MustParse("1.5.0")}
+	b := &Constraint{Gte: MustParse("2.0.0")}
+	c := &Constraint{Gte: MustParse("1.3.4"), Lte: MustParse("1.3.4")}
+
+	testCases := []TestCase{
+		{V: MustParse("1.3.0"), M: Union{a, b}, Expected: true},
+		{V: MustParse("1.6.0"), M: Union{a, b}, Expected: false},
+		{V: MustParse("2.1.0"), M: Union{a, b}, Expected: true},
+		{V: MustParse("1.0.0"), M: Union{}, Expected: false},
+
+		{V: MustParse("1.3.0"), M: Intersection{a, Exclusion{Matcher: c}}, Expected: true},
+		{V: MustParse("1.3.4"), M: Intersection{a, Exclusion{Matcher: c}}, Expected: false},
+		{V: MustParse("1.6.0"), M: Intersection{a, Exclusion{Matcher: c}}, Expected: false},
+		{V: MustParse("1.0.0"), M: Intersection{}, Expected: true},
+
+		{V: MustParse("1.3.4"), M: Exclusion{Matcher: c}, Expected: false},
+		{V: MustParse("1.3.5"), M: Exclusion{Matcher: c}, Expected: true},
+		{V: MustParse("1.3.5"), M: Exclusion{}, Expected: true},
+
+		{V: MustParse("1.3.4"), M: Union{Intersection{a, Exclusion{Matcher: c}}, b}, Expected: false},
+		{V: MustParse("2.3.4"), M: Union{Intersection{a, Exclusion{Matcher: c}}, b}, Expected: true},
+
+		{V: MustParse("1.0.0"), M: Union{a, nil}, Expected: true},
+		{V: MustParse("1.0.0"), M: Intersection{nil, Exclusion{Matcher: c}}, Expected: true},
+
+		{M: Union{a, b}, Expected: false},
+		{M: Intersection{}, Expected: false},
+		{M: Exclusion{Matcher: c}, Expected: false},
+	}
+
+	for i, testCase := range testCases {
+		actual := testCase.V.Match(testCase.M)
+		if actual != testCase.Expected {
+			t.Errorf("test %d failed (expected %v, actual %v)", i, testCase.Expected, actual)
+		} else {
+			t.Logf("test %d passed with %v", i, actual)
+		}
+	}
+}
+
+func TestUnionOf_Match(t *testing.T) {
+	u := UnionOf[*Debian]{&ConstraintOf[*Debian]{Lt: MustParseDebian("1.0")}, nil}
+	if !u.Match(MustParseDebian("2.0")) || u.Match(nil) {
+		t.Errorf("expected nil constraint in %s to match any version", u)
+	}
+	if s := (UnionOf[*Debian]{}).String(); s != "!=*" {
+		t.Errorf("expected !=*, actual %s", s)
+	}
+}
@@ -0,0 +1,423 @@
+package version
+
+import "strings"
+
+// mavenQualifiers are the well-known qualifiers of Maven versions, in order of precedence.
+// The empty qualifier represents a release, so a version with any qualifier before it, such as 1.0-rc1, precedes the release 1.0.
+var mavenQualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
+
+// mavenAliases are alternative spellings of well-known qualifiers.
+var mavenAliases = map[string]string{
+	"cr":      "rc",
+	"final":   "",
+	"ga":      "",
+	"release": "",
+}
+
+// Kinds of item in a Maven version.
+const (
+	mavenNumber = iota
+	mavenString
+	mavenList
+)
+
+// mavenItem is a component of a Maven version: a number, a qualifier, or a list of items following a hyphen.
+type mavenItem struct {
+	kind  int
+	value string      // Number without leading zeros, or qualifier with aliases resolved.
+	items []mavenItem // Items of a list.
+}
+
+// Maven is a Maven artifact version, such as 1.0-SNAPSHOT or 2.0.0.Final.
+// Versions are ordered as by org.apache.maven.artifact.versioning.ComparableVersion in Maven 3.
+// See https://maven.apache.org/pom.html#version-order-specification
+type Maven struct {
+	Text string // Original version string.
+
+	items mavenItem
+}
+
+// MustParseMaven parses a Maven version, and panics if it is invalid.
+func MustParseMaven(str string) *Maven {
+	v, err := ParseMaven(str)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// ParseMaven parses a Maven version.
+//
+// Maven accepts any string as a version, splitting it into numbers and qualifiers at periods, hyphens and transitions between digits and letters.
+// Qualifiers are case-insensitive, and the well-known qualifiers alpha, beta, milestone, rc, snapshot and sp have special precedence.
+// Only an empty string, or a string containing whitespace, is rejected.
+func ParseMaven(str string) (*Maven, error) {
+	if str == "" {
+		return nil, newParseError(ErrInvalidMaven, KindEmpty, str, SectionMajor, 0)
+	} else if strings.ContainsAny(str, " \t\n\r") {
+		return nil, newError(ErrInvalidMaven, str)
+	}
+
+	return &Maven{Text: str, items: parseMavenItems(strings.ToLower(str))}, nil
+}
+
+// Canonical returns the canonical form of the version, as given by ComparableVersion.getCanonical.
+// Versions with the same canonical form are equal, so 1.0.0.RELEASE and 1 are both written as 1.
+func (v *Maven) Canonical() string {
+	if v == nil {
+		return ""
+	}
+	return v.items.String()
+}
+
+// Compare this version (a) with another version (b), in the same order as Maven.
+// This function returns -1 if a is less than b, 1 if a is greater than b, or 0 if a is equal to b.
+//
+// Numbers are compared numerically, and trailing zeros and release qualifiers such as final and ga are ignored, so 1.0.0.Final is equal to 1.
+// Qualifiers are ordered alpha < beta < milestone < rc < snapshot < release < sp, followed by any other qualifiers in lexical order.
+// A single letter followed by a number is read as a well-known qualifier, so 1.0a1 is equal to 1.0-alpha-1.
+//
+// A nil version is less than any other version.
+func (a *Maven) Compare(b *Maven) int {
+	if a == nil {
+		if b == nil {
+			return 0
+		}
+		return -1
+	} else if b == nil {
+		return 1
+	}
+
+	return a.items.compare(&b.items)
+}
+
+// Equal determines whether this version (a) is equal to another version (b).
+func (a *Maven) Equal(b *Maven) bool {
+	return a.Compare(b) == 0
+}
+
+// Less determines whether this version (a) is less than another version (b).
+func (a *Maven) Less(b *Maven) bool {
+	return a.Compare(b) < 0
+}
+
+// Match tests the version against a constraint, such as one returned by ParseMavenRange.
+// If the constraint is nil, this function returns true.
+func (v *Maven) Match(m MatcherOf[*Maven]) bool {
+	if v == nil {
+		return false
+	}
+	if m == nil {
+		return true
+	}
+	return m.Match(v)
+}
+
+func (v *Maven) String() string {
+	if v == nil {
+		return ""
+	}
+	return v.Text
+}
+
+// ParseMavenRange parses a version range in Maven bracket notation, such as [1.0,2.0) or (,1.5],[1.7,).
+//
+// A square bracket is an inclusive bound and a parenthesis is an exclusive bound.
+// An empty bound leaves that end of the range unbounded, and a single version in square brackets, such as [1.0], matches that version only.
+// A single range is returned as a *ConstraintOf, and comma-separated ranges are returned as a UnionOf constraints.
+//
+// A version without brackets, such as 1.0, is a soft requirement in Maven, which recommends that version but allows any other.
+// It is returned as a constraint with no bounds.
+//
+// See https://maven.apache.org/pom.html#dependency-version-requirement-specification
+func ParseMavenRange(str string) (MatcherOf[*Maven], error) {
+	rest := strings.TrimSpace(str)
+	if rest == "" {
+		return nil, newError(ErrInvalidMavenRange, str)
+	}
+
+	if rest[0] != '[' && rest[0] != '(' {
+		if _, err := ParseMaven(rest); err != nil {
+			return nil, newError(ErrInvalidMavenRange, str)
+		}
+		return &ConstraintOf[*Maven]{}, nil
+	}
+
+	ranges := []*interval[*Maven]{}
+	for rest != "" {
+		if rest[0] != '[' && rest[0] != '(' {
+			return nil, newError(ErrInvalidMavenRange, str)
+		}
+		end := strings.IndexAny(rest, "])")
+		if end < 0 {
+			return nil, newError(ErrInvalidMavenRange, str)
+		}
+
+		r, ok := parseMavenRestriction(rest[:end+1])
+		if !ok {
+			return nil, newError(ErrInvalidMavenRange, str)
+		}
+		if len(ranges) > 0 {
+			prev := ranges[len(ranges)-1]
+			if prev.Upper == nil || r.Lower == nil || r.Lower.Version.Compare(prev.Upper.Version) < 0 {
+				return nil, newError(ErrInvalidMavenRange, str)
+			}
+		}
+		ranges = append(ranges, r)
+
+		rest = strings.TrimSpace(rest[end+1:])
+		if strings.HasPrefix(rest, ",") {
+			rest = strings.TrimSpace(rest[1:])
+			if rest == "" {
+				return nil, newError(ErrInvalidMavenRange, str)
+			}
+		}
+	}
+
+	if len(ranges) == 1 {
+		return ranges[0].Constraint(), nil
+	}
+	u := UnionOf[*Maven]{}
+	for _, r := range ranges {
+		u = append(u, r.Constraint())
+	}
+	return u, nil
+}
+
+// parseMavenRestriction parses a single range in Maven bracket notation, such as [1.0,2.0).
+// If the range is not valid, false is returned.
+func parseMavenRestriction(str string) (*interval[*Maven], bool) {
+	r := &interval[*Maven]{}
+	lowerInclusive := str[0] == '['
+	upperInclusive := str[len(str)-1] == ']'
+	inner := str[1 : len(str)-1]
+
+	lower, upper, ok := strings.Cut(inner, ",")
+	if strings.Contains(upper, ",") {
+		return r, false
+	} else if !ok {
+		// [1.0] matches exactly one version
+		v, err := ParseMaven(strings.TrimSpace(inner))
+		if err != nil || !lowerInclusive || !upperInclusive {
+			return r, false
+		}
+		r.Apply("=", v)
+		return r, true
+	}
+
+	if lower = strings.TrimSpace(lower); lower != "" {
+		v, err := ParseMaven(lower)
+		if err != nil {
+			return r, false
+		}
+		r.Lower = &bound[*Maven]{Version: v, Inclusive: lowerInclusive}
+	}
+	if upper = strings.TrimSpace(upper); upper != "" {
+		v, err := ParseMaven(upper)
+		if err != nil {
+			return r, false
+		}
+		r.Upper = &bound[*Maven]{Version: v, Inclusive: upperInclusive}
+	}
+
+	if r.Lower != nil && r.Upper != nil {
+		cmp := r.Lower.Version.Compare(r.Upper.Version)
+		if cmp > 0 || cmp == 0 && !(lowerInclusive && upperInclusive) {
+			return r, false
+		}
+	}
+	return r, true
+}
+
+// parseMavenItems splits a lower-case Maven version into items, as in ComparableVersion.parseVersion.
+func parseMavenItems(str string) mavenItem {
+	root := mavenItem{kind: mavenList}
+	stack := []*mavenItem{&root}
+	list := &root
+
+	// push starts a new list within the current list, for items following a hyphen or a transition between digits and letters
+	push := func() {
+		list.items = append(list.items, mavenItem{kind: mavenList})
+		list = &list.items[len(list.items)-1]
+		stack = append(stack, list)
+	}
+
+	digits := false
+	start := 0
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		switch {
+		case c == '.' || c == '-':
+			if i == start {
+				list.items = append(list.items, mavenItem{kind: mavenNumber, value: ""})
+			} else {
+				list.items = append(list.items, newMavenItem(str[start:i], digits, false))
+			}
+			start = i + 1
+			if c == '-' {
+				push()
+			}
+		case isDigit(c):
+			if !digits && i > start {
+				// 1.0.0.X1 < 1.0.0-X2, so X1 is read as X-1
+				list.items = append(list.items, newMavenItem(str[start:i], false, true))
+				start = i
+				push()
+			}
+			digits = true
+		default:
+			if digits && i > start {
+				list.items = append(list.items, newMavenItem(str[start:i], true, false))
+				start = i
+				push()
+			}
+			digits = false
+		}
+	}
+	if len(str) > start {
+		list.items = append(list.items, newMavenItem(str[start:], digits, false))
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		stack[i].normalize()
+	}
+	return root
+}
+
+// newMavenItem returns a number or qualifier item.
+// A single-letter qualifier followed by a number is an abbreviation for alpha, beta or milestone.
+func newMavenItem(str string, digits, followedByDigit bool) mavenItem {
+	if digits {
+		return mavenItem{kind: mavenNumber, value: strings.TrimLeft(str, "0")}
+	}
+
+	if followedByDigit && len(str) == 1 {
+		switch str {
+		case "a":
+			str = "alpha"
+		case "b":
+			str = "beta"
+		case "m":
+			str = "milestone"
+		}
+	}
+	if alias, ok := mavenAliases[str]; ok {
+		str = alias
+	}
+	return mavenItem{kind: mavenString, value: str}
+}
+
+// compare compares two items, as in the compareTo methods of ComparableVersion.
+// A nil item represents a missing item, which is equivalent to zero, a release or an empty list.
+func (a *mavenItem) compare(b *mavenItem) int {
+	switch a.kind {
+	case mavenNumber:
+		switch {
+		case b == nil:
+			if a.isNull() {
+				return 0
+			}
+			return 1
+		case b.kind == mavenNumber:
+			return compareDigits(a.value, b.value)
+		}
+		// 1.1 > 1-sp and 1.1 > 1-1
+		return 1
+
+	case mavenString:
+		switch {
+		case b == nil:
+			return strings.Compare(mavenQualifierKey(a.value), mavenQualifierKey(""))
+		case b.kind == mavenString:
+			return strings.Compare(mavenQualifierKey(a.value), mavenQualifierKey(b.value))
+		}
+		// 1-rc < 1.1 and 1-sp < 1-1
+		return -1
+	}
+
+	switch {
+	case b == nil:
+		if len(a.items) == 0 {
+			return 0
+		}
+		return a.items[0].compare(nil)
+	case b.kind == mavenNumber:
+		// 1-1 < 1.1
+		return -1
+	case b.kind == mavenString:
+		// 1-1 > 1-sp
+		return 1
+	}
+
+	for i := 0; i < len(a.items) || i < len(b.items); i++ {
+		var cmp int
+		switch {
+		case i >= len(a.items):
+			cmp = -b.items[i].compare(nil)
+		case i >= len(b.items):
+			cmp = a.items[i].compare(nil)
+		default:
+			cmp = a.items[i].compare(&b.items[i])
+		}
+		if cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
+// isNull determines whether the item is equivalent to a missing item: zero, a release qualifier, or an empty list.
+func (m *mavenItem) isNull() bool {
+	switch m.kind {
+	case mavenNumber, mavenString:
+		return m.value == ""
+	}
+	return len(m.items) == 0
+}
+
+// normalize removes trailing items from a list that are equivalent to missing items, such as the zeros in 1.0.0.
+func (m *mavenItem) normalize() {
+	for i := len(m.items) - 1; i >= 0; i-- {
+		if m.items[i].isNull() {
+			m.items = append(m.items[:i], m.items[i+1:]...)
+		} else if m.items[i].kind != mavenList {
+			break
+		}
+	}
+}
+
+func (m mavenItem) String() string {
+	switch m.kind {
+	case mavenNumber:
+		if m.value == "" {
+			return "0"
+		}
+		return m.value
+	case mavenString:
+		return m.value
+	}
+
+	str := strings.Builder{}
+	for i, item := range m.items {
+		if i > 0 {
+			if item.kind == mavenList {
+				str.WriteByte('-')
+			} else {
+				str.WriteByte('.')
+			}
+		}
+		str.WriteString(item.String())
+	}
+	return str.String()
+}
+
+// mavenQualifierKey returns a key by which qualifiers can be ordered lexically.
+// Well-known qualifiers are ordered by precedence, followed by any other qualifiers in lexical order.
+func mavenQualifierKey(q string) string {
+	for i, known := range mavenQualifiers {
+		if q == known {
+			return string(rune('0' + i))
+		}
+	}
+	return string(rune('0'+len(mavenQualifiers))) + "-" + q
+}
@@ -0,0 +1,162 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func TestParseMaven(t *testing.T) {
+	type TestCase struct {
+		Input     string
+		Canonical string
+		Err       error
+	}
+
+	testCases := []TestCase{
+		{Input: "1.0-SNAPSHOT", Canonical: "1-snapshot"},
+		{Input: "2.0.0.Final", Canonical: "2"},
+		{Input: "1.0-alpha-1", Canonical: "1-alpha-1"},
+		{Input: "1.0.0.RELEASE", Canonical: "1"},
+		{Input: "1.0a1", Canonical: "1-alpha-1"},
+		{Input: "1.2.0-CR2", Canonical: "1.2-rc-2"},
+		{Input: "1.0.1.0.0", Canonical: "1.0.1"},
+		{Input: "1..1", Canonical: "1.0.1"},
+		{Input: "2.1b", Canonical: "2.1-b"},
+		{Input: "", Err: ErrInvalidMaven},
+		{Input: "1.0 beta", Err: ErrInvalidMaven},
+	}
+
+	for i, testCase := range testCases {
+		actual, err := ParseMaven(testCase.Input)
+
+		if testCase.Err != nil {
+			if !errors.Is(err, testCase.Err) {
+				t.Errorf("test %d failed (expected error %s, actual %v)", i, testCase.Err, err)
+			} else {
+				t.Logf("test %d passed with error %s for %q\n", i, err, testCase.Input)
+			}
+		} else if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+		} else if actual.Canonical() != testCase.Canonical {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, testCase.Canonical, actual.Canonical())
+		} else {
+			t.Logf("test %d passed with %s", i, actual)
+		}
+	}
+}
+
+func TestMaven_Compare(t *testing.T) {
+	// Orderings from the test suite of ComparableVersion
+	orderings := [][]string{
+		{
+			"1-alpha2snapshot", "1-alpha2", "1-alpha-123", "1-beta-2", "1-beta123", "1-m2", "1-m11", "1-rc", "1-cr2", "1-rc123",
+			"1-SNAPSHOT", "1", "1-sp", "1-sp2", "1-sp123", "1-abc", "1-def", "1-pom-1", "1-1-snapshot", "1-1", "1-2", "1-123",
+		},
+		{
+			"2.0", "2-1", "2.0.a", "2.0.0.a", "2.0.2", "2.0.123", "2.1.0", "2.1-a", "2.1b", "2.1-c", "2.1-1", "2.1.0.1", "2.2", "2.123",
+			"11.a2", "11.a11", "11.b2", "11.b11", "11.m2", "11.m11", "11", "11.a", "11b", "11c", "11m",
+		},
+	}
+
+	for _, ordered := range orderings {
+		for i := 1; i < len(ordered); i++ {
+			a := MustParseMaven(ordered[i-1])
+			b := MustParseMaven(ordered[i])
+			if a.Compare(b) != -1 || b.Compare(a) != 1 {
+				t.Errorf("test %d failed (expected %s < %s)", i, a, b)
+			}
+		}
+
+		list := ListOf[*Maven]{}
+		for i := len(ordered) - 1; i >= 0; i-- {
+			list = append(list, MustParseMaven(ordered[i]))
+		}
+		sort.Sort(list)
+		for i, v := range list {
+			if v.Text != ordered[i] {
+				t.Errorf("sort failed at position %d (expected %s, actual %s)", i, ordered[i], v)
+			}
+		}
+	}
+
+	equal := [][]string{
+		{"1", "1.0", "1.0.0", "1-ga", "1-final", "1.0.0.RELEASE", "1.0.0.Final"},
+		{"1a1", "1-a1", "1-alpha-1", "1.0-ALPHA-1"},
+		{"1cr", "1rc", "1-rc", "1-CR"},
+		{"1x", "1-x", "1X"},
+	}
+	for _, versions := range equal {
+		for _, str := range versions[1:] {
+			if cmp := MustParseMaven(versions[0]).Compare(MustParseMaven(str)); cmp != 0 {
+				t.Errorf("expected %s == %s, actual %d", versions[0], str, cmp)
+			}
+		}
+	}
+}
+
+func TestParseMavenRange(t *testing.T) {
+	type TestCase struct {
+		Input    string
+		Expected string
+		Match    []string
+		NoMatch  []string
+		Err      error
+	}
+
+	testCases := []TestCase{
+		{Input: "[1.0,2.0)", Expected: ">=1 <2", Match: []string{"1.0", "1.0.0.Final", "1.5-SNAPSHOT"}, NoMatch: []string{"1.0-SNAPSHOT", "2.0", "2"}},
+		{Input: "(,1.5],[1.7,)", Expected: "<=1.5 || >=1.7", Match: []string{"1.0", "1.5", "1.7", "3"}, NoMatch: []string{"1.6", "1.7-rc1"}},
+		{Input: "[1.0]", Expected: "=1", Match: []string{"1", "1.0.0"}, NoMatch: []string{"1.0.1"}},
+		{Input: "(1.0,)", Expected: ">1", Match: []string{"1.0-sp1", "1.1"}, NoMatch: []string{"1.0"}},
+		{Input: " [ 1.0 , 2.0 ] ", Expected: ">=1 <=2", Match: []string{"2.0"}, NoMatch: []string{"2.0.1"}},
+		{Input: "(,1.0],[1.2,1.5),[2.0,)", Expected: "<=1 || >=1.2 <1.5 || >=2", Match: []string{"1.2", "2.1"}, NoMatch: []string{"1.1", "1.5"}},
+		{Input: "1.0", Expected: "*", Match: []string{"0.1", "2.0"}},
+		{Input: "", Err: ErrInvalidMavenRange},
+		{Input: "[1.0", Err: ErrInvalidMavenRange},
+		{Input: "(1.0)", Err: ErrInvalidMavenRange},
+		{Input: "[2.0,1.0]", Err: ErrInvalidMavenRange},
+		{Input: "[1.0,1.0)", Err: ErrInvalidMavenRange},
+		{Input: "[1.0,2.0,3.0]", Err: ErrInvalidMavenRange},
+		{Input: "[1.0,2.0],[1.5,3.0]", Err: ErrInvalidMavenRange},
+		{Input: "[1.0,2.0),", Err: ErrInvalidMavenRange},
+		{Input: "[1.0,2.0)1.5", Err: ErrInvalidMavenRange},
+	}
+
+	for i, testCase := range testCases {
+		actual, err := ParseMavenRange(testCase.Input)
+
+		if testCase.Err != nil {
+			if !errors.Is(err, testCase.Err) {
+				t.Errorf("test %d failed (expected error %s, actual %v)", i, testCase.Err, err)
+			} else {
+				t.Logf("test %d passed with error %s for %q\n", i, err, testCase.Input)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+			continue
+		} else if str := fmt.Sprint(actual); str != testCase.Expected {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, testCase.Expected, str)
+			continue
+		}
+
+		ok := true
+		for _, str := range testCase.Match {
+			if !MustParseMaven(str).Match(actual) {
+				ok = false
+				t.Errorf("test %d failed (expected %s to match %s)", i, str, testCase.Input)
+			}
+		}
+		for _, str := range testCase.NoMatch {
+			if MustParseMaven(str).Match(actual) {
+				ok = false
+				t.Errorf("test %d failed (expected %s not to match %s)", i, str, testCase.Input)
+			}
+		}
+		if ok {
+			t.Logf("test %d passed for %s", i, testCase.Input)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package version
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pseudoTimeFormat is the layout of the timestamp in a pseudo-version.
+const pseudoTimeFormat = "20060102150405"
+
+// ModuleVersion is a Go module version, such as v1.2.3, v2.0.0+incompatible or a pseudo-version.
+//
+// Versions are compared in the same order as golang.org/x/mod/semver, which ignores build metadata such as +incompatible.
+type ModuleVersion struct {
+	*Version
+
+	Incompatible bool    // Whether the version has the +incompatible suffix, for a major version of 2 or more without a go.mod file.
+	Pseudo       *Pseudo // Pseudo-version details, or nil if this is not a pseudo-version.
+}
+
+// Pseudo describes a Go module pseudo-version, which refers to a specific revision with no tag.
+// See https://go.dev/ref/mod#pseudo-versions
+type Pseudo struct {
+	Base     *Version  // Tagged version on which the pseudo-version is based, or nil if there is none, as in v0.0.0-20231010123456-abcdef123456.
+	Time     time.Time // Commit time of the revision, in UTC.
+	Revision string    // Commit hash prefix of the revision, such as abcdef123456.
+}
+
+// ParseModule parses a Go module version.
+//
+// The version must have a v prefix and otherwise conform to Semantic Versioning 2.0.0, as checked by ParseStrict.
+// As in golang.org/x/mod/semver, the shorthand forms v1 and v1.2 are accepted for v1.0.0 and v1.2.0.
+// The only build metadata allowed is +incompatible, which requires a major version of 2 or more.
+//
+// Pseudo-versions, in any of the three forms used by the go command, are recognized and their details are stored in Pseudo.
+func ParseModule(str string) (*ModuleVersion, error) {
+	if len(str) == 0 {
+		return nil, newParseError(ErrInvalidModuleVersion, KindEmpty, str, SectionMajor, 0)
+	} else if str[0] != 'v' {
+		return nil, newParseError(ErrInvalidModuleVersion, KindInvalidCharacter, str, SectionMajor, 0)
+	}
+
+	semantic := str[1:]
+	if strings.IndexAny(semantic, "-+") < 0 {
+		switch strings.Count(semantic, ".") {
+		case 0:
+			semantic += ".0.0"
+		case 1:
+			semantic += ".0"
+		}
+	}
+
+	v, err := ParseStrict(semantic)
+	if err != nil {
+		e := Error{}
+		if errors.As(err, &e) && e.Section != SectionNone {
+			return nil, newParseError(ErrInvalidModuleVersion, e.Kind, str, e.Section, min(e.Offset+1, len(str)))
+		}
+		return nil, newError(ErrInvalidModuleVersion, str)
+	}
+	v.Text = str
+
+	m := &ModuleVersion{Version: v}
+	if len(v.Build) > 0 {
+		if joinIdentifiers(v.Build) != "incompatible" {
+			return nil, newParseError(ErrInvalidModuleVersion, KindNone, str, SectionExtension, strings.IndexByte(str, '+'))
+		}
+		if v.Major < 2 {
+			return nil, newParseError(ErrIncompatible, KindNone, str, SectionMajor, 1)
+		}
+		m.Incompatible = true
+	}
+
+	if p, ok, err := parsePseudo(v); err != nil {
+		return nil, err
+	} else if ok {
+		m.Pseudo = p
+	}
+
+	return m, nil
+}
+
+// Canonical returns the canonical form of the version, as given by golang.org/x/mod/semver.Canonical.
+// For example, v1.2 becomes v1.2.0.
+func (m *ModuleVersion) Canonical() string {
+	if m == nil || m.Version == nil {
+		return ""
+	}
+
+	str := "v" + m.Release().SemanticString() + formatExtension(m.PreRelease, nil)
+	if m.Incompatible {
+		str += "+incompatible"
+	}
+	return str
+}
+
+// CheckPath checks that the version is allowed for a module path, according to its major version suffix.
+//
+// A path ending in /vN, such as example.com/mod/v2, requires a major version of N, and does not allow +incompatible.
+// A path without a suffix requires a major version of 0 or 1, or an +incompatible version.
+// Paths on gopkg.in use a .vN suffix, such as gopkg.in/yaml.v3.
+//
+// See https://go.dev/ref/mod#major-version-suffixes
+func (m *ModuleVersion) CheckPath(path string) error {
+	pathMajor, ok := modulePathMajor(path)
+	if !ok {
+		return newError(ErrModulePath, path+"@"+m.String())
+	}
+
+	major := strconv.Itoa(m.Major)
+	if m.Large[sectionMajor] != "" {
+		major = string(m.Large[sectionMajor])
+	}
+
+	if pathMajor == "" {
+		if m.Major <= 1 || m.Incompatible {
+			return nil
+		}
+	} else if strings.HasPrefix(pathMajor, ".v") {
+		// gopkg.in allowed v0.0.0 pseudo-versions for .v1 paths
+		if pathMajor[2:] == major || pathMajor == ".v1" && m.Pseudo != nil && m.Pseudo.Base == nil && m.Major == 0 {
+			return nil
+		}
+	} else if pathMajor[2:] == major && !m.Incompatible {
+		return nil
+	}
+
+	return newError(ErrModulePath, path+"@"+m.String())
+}
+
+// Compare this version (a) with another version (b), in the same order as golang.org/x/mod/semver.Compare.
+// This function returns -1 if a is less than b, 1 if a is greater than b, or 0 if a is equal to b.
+//
+// A nil version is less than any other version.
+func (a *ModuleVersion) Compare(b *ModuleVersion) int {
+	var av, bv *Version
+	if a != nil {
+		av = a.Version
+	}
+	if b != nil {
+		bv = b.Version
+	}
+	return av.Compare(bv)
+}
+
+// modulePathMajor returns the major version suffix of a module path, such as /v2 or .v3, or an empty string if it has none.
+// If the suffix is not valid, such as /v1 or /v02, false is returned.
+func modulePathMajor(path string) (string, bool) {
+	if strings.HasPrefix(path, "gopkg.in/") {
+		i := strings.LastIndex(path, ".v")
+		if i < 0 || strings.Contains(path[i:], "/") {
+			return "", false
+		}
+		n := strings.TrimSuffix(path[i+2:], "-unstable")
+		if !isNumeric(n) || len(n) > 1 && n[0] == '0' {
+			return "", false
+		}
+		return ".v" + n, true
+	}
+
+	i := strings.LastIndexByte(path, '/')
+	if i < 0 || !strings.HasPrefix(path[i+1:], "v") || !isNumeric(path[i+2:]) {
+		return "", true
+	}
+	n := path[i+2:]
+	if n[0] == '0' || n == "1" {
+		return "", false
+	}
+	return "/v" + n, true
+}
+
+// parsePseudo returns the details of a pseudo-version.
+// If the version is not a pseudo-version, false is returned.
+// An error is returned if the version has the form of a pseudo-version but no valid base version.
+func parsePseudo(v *Version) (*Pseudo, bool, error) {
+	pre := v.PreRelease
+	if len(pre) == 0 {
+		return nil, false, nil
+	}
+
+	last := string(pre[len(pre)-1])
+	i := strings.IndexByte(last, '-')
+	if i != len(pseudoTimeFormat) || !isNumeric(last[:i]) || last[i+1:] == "" {
+		return nil, false, nil
+	}
+	for j := i + 1; j < len(last); j++ {
+		if !isAlphanumeric(last[j]) {
+			return nil, false, nil
+		}
+	}
+
+	t, err := time.Parse(pseudoTimeFormat, last[:i])
+	if err != nil {
+		return nil, false, nil
+	}
+	p := &Pseudo{Time: t, Revision: last[i+1:]}
+
+	switch {
+	case len(pre) == 1:
+		// vX.0.0-yyyymmddhhmmss-abcdef123456
+		if v.Minor != 0 || v.Patch != 0 {
+			return nil, false, nil
+		}
+	case pre[len(pre)-2] != "0":
+		return nil, false, nil
+	case len(pre) == 2:
+		// vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdef123456
+		if v.Patch == 0 {
+			return nil, false, newParseError(ErrInvalidPseudoVersion, KindNone, v.Text, SectionPatch, strings.IndexByte(v.Text, '-')-1)
+		}
+		p.Base = v.Release()
+		p.Base.Patch--
+		if p.Base.Large[sectionPatch] != "" {
+			p.Base.setNumber(sectionPatch, decrementDigits(string(p.Base.Large[sectionPatch])))
+		}
+	default:
+		// vX.Y.Z-pre.0.yyyymmddhhmmss-abcdef123456
+		p.Base = v.WithPreRelease(pre[:len(pre)-2]...)
+	}
+
+	return p, true, nil
+}
+
+// decrementDigits subtracts one from a string of decimal digits greater than zero.
+func decrementDigits(digits string) string {
+	b := []byte(digits)
+	i := len(b) - 1
+	for ; i >= 0 && b[i] == '0'; i-- {
+		b[i] = '9'
+	}
+	b[i]--
+	return string(newNumber(string(b)))
+}
@@ -0,0 +1,202 @@
+package version
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseModule(t *testing.T) {
+	type TestCase struct {
+		Input        string
+		Canonical    string
+		Incompatible bool
+		Base         string
+		Time         time.Time
+		Revision     string
+		Pseudo       bool
+		Err          error
+	}
+
+	testCases := []TestCase{
+		{Input: "v1.2.3", Canonical: "v1.2.3"},
+		{Input: "v1.2", Canonical: "v1.2.0"},
+		{Input: "v1", Canonical: "v1.0.0"},
+		{Input: "v1.2.3-rc.1", Canonical: "v1.2.3-rc.1"},
+		{Input: "v2.0.0+incompatible", Canonical: "v2.0.0+incompatible", Incompatible: true},
+		{Input: "v0.0.0-20231010123456-abcdef123456", Canonical: "v0.0.0-20231010123456-abcdef123456", Pseudo: true, Time: time.Date(2023, 10, 10, 12, 34, 56, 0, time.UTC), Revision: "abcdef123456"},
+		{Input: "v2.0.0-20231010123456-abcdef123456+incompatible", Canonical: "v2.0.0-20231010123456-abcdef123456+incompatible", Incompatible: true, Pseudo: true, Time: time.Date(2023, 10, 10, 12, 34, 56, 0, time.UTC), Revision: "abcdef123456"},
+		{Input: "v1.2.4-0.20231010123456-abcdef123456", Canonical: "v1.2.4-0.20231010123456-abcdef123456", Pseudo: true, Base: "1.2.3", Time: time.Date(2023, 10, 10, 12, 34, 56, 0, time.UTC), Revision: "abcdef123456"},
+		{Input: "v1.2.3-rc.1.0.20231010123456-abcdef123456", Canonical: "v1.2.3-rc.1.0.20231010123456-abcdef123456", Pseudo: true, Base: "1.2.3-rc.1", Time: time.Date(2023, 10, 10, 12, 34, 56, 0, time.UTC), Revision: "abcdef123456"},
+		{Input: "v1.2.3-20231010123456-abcdef123456", Canonical: "v1.2.3-20231010123456-abcdef123456"},
+		{Input: "v1.2.3-0.20231399123456-abcdef123456", Canonical: "v1.2.3-0.20231399123456-abcdef123456"},
+		{Input: "1.2.3", Err: ErrInvalidModuleVersion},
+		{Input: "v1.02.3", Err: ErrLeadingZero},
+		{Input: "v1.2-rc.1", Err: ErrInvalidModuleVersion},
+		{Input: "v1.2.3+build.5", Err: ErrInvalidModuleVersion},
+		{Input: "v1.2.3+incompatible", Err: ErrIncompatible},
+		{Input: "v1.2.0-0.20231010123456-abcdef123456", Err: ErrInvalidPseudoVersion},
+	}
+
+	for i, testCase := range testCases {
+		actual, err := ParseModule(testCase.Input)
+
+		if testCase.Err != nil {
+			if err == nil {
+				t.Errorf("test %d failed (expected error %s, actual nil)", i, testCase.Err)
+			} else if !errors.Is(err, testCase.Err) {
+				t.Errorf("test %d failed (expected error %s, actual error %s)", i, testCase.Err, err)
+			} else {
+				t.Logf("test %d passed with error %s for %q\n", i, err, testCase.Input)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+			continue
+		}
+
+		if actual.Canonical() != testCase.Canonical || actual.Incompatible != testCase.Incompatible || actual.String() != testCase.Input {
+			t.Errorf("test %d failed (expected %s, actual %s incompatible %t)", i, testCase.Canonical, actual.Canonical(), actual.Incompatible)
+		} else if (actual.Pseudo != nil) != testCase.Pseudo {
+			t.Errorf("test %d failed (expected pseudo-version %t, actual %+v)", i, testCase.Pseudo, actual.Pseudo)
+		} else if p := actual.Pseudo; p != nil && (p.Base.SemanticString() != testCase.Base || !p.Time.Equal(testCase.Time) || p.Revision != testCase.Revision) {
+			t.Errorf("test %d failed (expected base %q time %s revision %s, actual base %q time %s revision %s)", i, testCase.Base, testCase.Time, testCase.Revision, p.Base.SemanticString(), p.Time, p.Revision)
+		} else {
+			t.Logf("test %d passed with %s", i, actual.Canonical())
+		}
+	}
+}
+
+func TestModuleVersion_Compare(t *testing.T) {
+	type TestCase struct {
+		A        string
+		B        string
+		Expected int
+	}
+
+	testCases := []TestCase{
+		{A: "v1.2.3", B: "v1.2.4", Expected: -1},
+		{A: "v1.2", B: "v1.2.0", Expected: 0},
+		{A: "v2.0.0+incompatible", B: "v2.0.0", Expected: 0},
+		{A: "v1.2.4-0.20231010123456-abcdef123456", B: "v1.2.3", Expected: 1},
+		{A: "v1.2.4-0.20231010123456-abcdef123456", B: "v1.2.4-rc.1", Expected: -1},
+		{A: "v1.2.4-0.20231010123456-abcdef123456", B: "v1.2.4-0.20231011000000-123456abcdef", Expected: -1},
+		{A: "v0.0.0-20231010123456-abcdef123456", B: "v0.0.1", Expected: -1},
+	}
+
+	for i, testCase := range testCases {
+		a, _ := ParseModule(testCase.A)
+		b, _ := ParseModule(testCase.B)
+
+		actual := a.Compare(b)
+		if actual != testCase.Expected {
+			t.Errorf("test %d failed (expected %d, actual %d)", i, testCase.Expected, actual)
+		} else {
+			t.Logf("test %d passed with %d", i, actual)
+		}
+	}
+}
+
+func TestModuleVersion_CheckPath(t *testing.T) {
+	type TestCase struct {
+		Path    string
+		Version string
+		Err     error
+	}
+
+	testCases := []TestCase{
+		{Path: "example.com/mod", Version: "v1.2.3"},
+		{Path: "example.com/mod", Version: "v0.0.0-20231010123456-abcdef123456"},
+		{Path: "example.com/mod", Version: "v2.0.0+incompatible"},
+		{Path: "example.com/mod/v2", Version: "v2.1.0"},
+		{Path: "example.com/mod/v10", Version: "v10.0.0-rc.1"},
+		{Path: "example.com/vendor", Version: "v1.0.0"},
+		{Path: "gopkg.in/yaml.v3", Version: "v3.0.1"},
+		{Path: "gopkg.in/check.v1", Version: "v0.0.0-20231010123456-abcdef123456"},
+		{Path: "example.com/mod", Version: "v2.0.0", Err: ErrModulePath},
+		{Path: "example.com/mod/v2", Version: "v3.0.0", Err: ErrModulePath},
+		{Path: "example.com/mod/v2", Version: "v1.0.0", Err: ErrModulePath},
+		{Path: "example.com/mod/v2", Version: "v2.0.0+incompatible", Err: ErrModulePath},
+		{Path: "example.com/mod/v1", Version: "v1.0.0", Err: ErrModulePath},
+		{Path: "example.com/mod/v02", Version: "v2.0.0", Err: ErrModulePath},
+		{Path: "gopkg.in/yaml.v3", Version: "v2.4.0", Err: ErrModulePath},
+	}
+
+	for i, testCase := range testCases {
+		m, err := ParseModule(testCase.Version)
+		if err != nil {
+			t.Fatalf("test %d failed to parse %s: %s", i, testCase.Version, err)
+		}
+
+		err = m.CheckPath(testCase.Path)
+		if testCase.Err == nil && err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+		} else if testCase.Err != nil && !errors.Is(err, testCase.Err) {
+			t.Errorf("test %d failed (expected error %s, actual error %v)", i, testCase.Err, err)
+		} else {
+			t.Logf("test %d passed with error %v for %s@%s", i, err, testCase.Path, testCase.Version)
+		}
+	}
+}
+
+func TestModuleVersion_Decode(t *testing.T) {
+	type TestCase struct {
+		Input        string
+		Into         *ModuleVersion
+		Expected     string
+		Incompatible bool
+		Pseudo       bool
+		Err          error
+	}
+
+	parse := func(str string) *ModuleVersion {
+		m, _ := ParseModule(str)
+		return m
+	}
+
+	testCases := []TestCase{
+		{Input: "v2.0.0+incompatible", Into: &ModuleVersion{}, Expected: "v2.0.0+incompatible", Incompatible: true},
+		{Input: "v2.0.0+incompatible", Into: parse("v1.2.3"), Expected: "v2.0.0+incompatible", Incompatible: true},
+		{Input: "v1.2", Into: parse("v2.0.0+incompatible"), Expected: "v1.2.0"},
+		{Input: "v1.2.4-0.20231010123456-abcdef123456", Into: parse("v1.0.0"), Expected: "v1.2.4-0.20231010123456-abcdef123456", Pseudo: true},
+		{Input: "1.2.3", Into: &ModuleVersion{}, Err: ErrInvalidModuleVersion},
+	}
+
+	for i, testCase := range testCases {
+		decoders := map[string]func(m *ModuleVersion) error{
+			"json": func(m *ModuleVersion) error { return json.Unmarshal([]byte(`"`+testCase.Input+`"`), m) },
+			"scan": func(m *ModuleVersion) error { return m.Scan([]byte(testCase.Input)) },
+		}
+		if testCase.Err == nil {
+			data, _ := parse(testCase.Input).MarshalBinary()
+			decoders["binary"] = func(m *ModuleVersion) error { return m.UnmarshalBinary(data) }
+		}
+
+		for name, decode := range decoders {
+			actual := *testCase.Into
+			err := decode(&actual)
+
+			if testCase.Err != nil {
+				if !errors.Is(err, testCase.Err) {
+					t.Errorf("test %d failed with %s (expected error %s, actual %v)", i, name, testCase.Err, err)
+				} else {
+					t.Logf("test %d passed with %s and error %s", i, name, err)
+				}
+			} else if err != nil {
+				t.Errorf("test %d failed with %s (expected error nil, actual error %s)", i, name, err)
+			} else if actual.Canonical() != testCase.Expected || actual.Incompatible != testCase.Incompatible || (actual.Pseudo != nil) != testCase.Pseudo {
+				t.Errorf("test %d failed with %s (expected %s incompatible %t, actual %s incompatible %t)", i, name, testCase.Expected, testCase.Incompatible, actual.Canonical(), actual.Incompatible)
+			} else {
+				t.Logf("test %d passed with %s and %s", i, name, actual.Canonical())
+			}
+		}
+	}
+
+	if err := (&ModuleVersion{}).Scan(nil); !errors.Is(err, ErrScanNull) {
+		t.Errorf("expected error %s, actual %v", ErrScanNull, err)
+	}
+	if v, err := parse("v2.0.0+incompatible").Value(); err != nil || v != "v2.0.0+incompatible" {
+		t.Errorf("value failed (expected v2.0.0+incompatible, actual %v with error %v)", v, err)
+	}
+}
@@ -0,0 +1,111 @@
+package version
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Number is a non-negative integer of any size, written as a string of decimal digits without leading zeros.
+// An empty Number is equal to zero.
+//
+// Numbers are used for the exact values of numeric components that are too large for an int.
+type Number string
+
+// Compare this number (a) with another number (b).
+// This function returns -1 if a is less than b, 1 if a is greater than b, or 0 if a is equal to b.
+func (a Number) Compare(b Number) int {
+	return compareDigits(string(a), string(b))
+}
+
+// Int returns the number as an int, if it is small enough.
+func (n Number) Int() (int, bool) {
+	i, err := strconv.Atoi(n.String())
+	return i, err == nil
+}
+
+func (n Number) String() string {
+	if n == "" {
+		return "0"
+	}
+	return string(n)
+}
+
+// isZero determines whether the number is equal to zero.
+func (n Number) isZero() bool {
+	return n == "" || n == "0"
+}
+
+// next returns the number plus one.
+func (n Number) next() Number {
+	return Number(incrementDigits(n.String()))
+}
+
+// newNumber normalizes a string of decimal digits into a Number by removing any leading zeros.
+func newNumber(digits string) Number {
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		return "0"
+	}
+	return Number(digits)
+}
+
+// Numbers returns the exact major, minor and patch numbers of the version, including any that are too large for an int, followed by any Segments.
+func (v *Version) Numbers() []Number {
+	if v == nil {
+		return nil
+	}
+
+	numbers := []Number{v.number(sectionMajor), v.number(sectionMinor), v.number(sectionPatch)}
+	return append(numbers, v.Segments...)
+}
+
+// number returns the exact value of a numeric component of the version.
+func (v *Version) number(section int) Number {
+	if v.Large[section] != "" {
+		return v.Large[section]
+	}
+
+	switch section {
+	case sectionMajor:
+		return Number(strconv.Itoa(v.Major))
+	case sectionMinor:
+		return Number(strconv.Itoa(v.Minor))
+	}
+	return Number(strconv.Itoa(v.Patch))
+}
+
+// setNumber sets a numeric component of the version from a string of decimal digits.
+// If the number is too large for an int, it is stored in Large and the int field is set to math.MaxInt.
+func (v *Version) setNumber(section int, digits string) {
+	n, err := strconv.Atoi(digits)
+	if err != nil {
+		v.setInt(section, math.MaxInt)
+		v.Large[section] = newNumber(digits)
+	} else {
+		v.setInt(section, n)
+	}
+}
+
+// setInt sets a numeric component of the version that fits in an int.
+func (v *Version) setInt(section int, n int) {
+	v.Large[section] = ""
+
+	switch section {
+	case sectionMajor:
+		v.Major = n
+	case sectionMinor:
+		v.Minor = n
+	case sectionPatch:
+		v.Patch = n
+	}
+}
+
+// sortNumbers returns the numbers of the version with any trailing zero Segments removed, so that versions which Compare considers equal have the same encoding.
+func (v *Version) sortNumbers() []Number {
+	numbers := v.Numbers()
+	for len(numbers) > 3 && numbers[len(numbers)-1].isZero() {
+		numbers = numbers[:len(numbers)-1]
+	}
+	return numbers
+}
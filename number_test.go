@@ -0,0 +1,58 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNumber_Compare(t *testing.T) {
+	type TestCase struct {
+		A        Number
+		B        Number
+		Expected int
+	}
+
+	testCases := []TestCase{
+		{A: "", B: "0", Expected: 0},
+		{A: "1", B: "2", Expected: -1},
+		{A: "10", B: "9", Expected: 1},
+		{A: "99999999999999999999", B: "99999999999999999999", Expected: 0},
+		{A: "99999999999999999999", B: "100000000000000000000", Expected: -1},
+		{A: "123456789012345678901234567890", B: "123456789012345678901234567891", Expected: -1},
+	}
+
+	for i, testCase := range testCases {
+		actual := testCase.A.Compare(testCase.B)
+		if actual != testCase.Expected {
+			t.Errorf("test %d failed (expected %d, actual %d)", i, testCase.Expected, actual)
+		} else {
+			t.Logf("test %d passed with %d", i, actual)
+		}
+	}
+}
+
+func TestVersion_Numbers(t *testing.T) {
+	type TestCase struct {
+		Input    *Version
+		Expected string
+	}
+
+	testCases := []TestCase{
+		{Input: MustParse("1.2.3"), Expected: "[1 2 3]"},
+		{Input: MustParse("v1.02"), Expected: "[1 2 0]"},
+		{Input: MustParse("1.2.0099999999999999999999"), Expected: "[1 2 99999999999999999999]"},
+	}
+
+	for i, testCase := range testCases {
+		numbers := testCase.Input.Numbers()
+		strs := make([]string, len(numbers))
+		for j, n := range numbers {
+			strs[j] = n.String()
+		}
+		if actual := "[" + strings.Join(strs, " ") + "]"; actual != testCase.Expected {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, testCase.Expected, actual)
+		} else {
+			t.Logf("test %d passed with %s", i, actual)
+		}
+	}
+}
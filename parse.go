@@ -1,15 +1,14 @@
 package version
 
 import (
-	"strconv"
+	"math"
 	"strings"
 )
 
 const (
-	sectionMajor     = 0
-	sectionMinor     = 1
-	sectionPatch     = 2
-	sectionExtension = 3
+	sectionMajor = 0
+	sectionMinor = 1
+	sectionPatch = 2
 )
 
 func MustParse(str string) *Version {
@@ -20,73 +19,170 @@ func MustParse(str string) *Version {
 	return v
 }
 
+// Parse parses a version string leniently.
+// A v prefix, missing minor or patch numbers, and any number of numeric Segments are accepted, and anything following the numbers is stored as the Extension.
+// A word following the patch number and a period, as in 1.0.0.Final, is also stored as the Extension, without the period.
+//
+// The numbers are read directly from the string, so parsing a version with no extension and at most three numbers allocates only the Version itself.
+// Any Segments after the patch number take one more allocation.
+// The Text and Extension of the version share memory with str.
 func Parse(str string) (*Version, error) {
-	v := &Version{Text: string(str)}
+	v := &Version{Text: str}
 
 	if len(str) == 0 {
-		return nil, invalid(str)
+		return nil, newParseError(ErrInvalidVersion, KindEmpty, str, SectionMajor, 0)
 	}
 
-	section := sectionMajor
-	chars := []byte{}
+	i := 0
+	if str[0] == 'v' || str[0] == 'V' {
+		i++
+	}
+	if i == len(str) {
+		return v, nil
+	}
 
-	commit := func() error {
-		if len(chars) == 0 {
-			return invalid(str)
+	for section := sectionMajor; i < len(str); section++ {
+		start := i
+		n := 0
+		overflow := false
+		for ; i < len(str) && str[i] >= '0' && str[i] <= '9'; i++ {
+			d := int(str[i] - '0')
+			if overflow || n > (math.MaxInt-d)/10 {
+				overflow = true
+			} else {
+				n = n*10 + d
+			}
 		}
 
-		if section < sectionExtension {
-			n, err := strconv.Atoi(string(chars))
-			if err != nil {
-				return invalid(str)
+		if i == start && section > sectionPatch && i < len(str) && str[i] != '.' {
+			// A word following the patch number, as in 1.0.0.Final, is not a segment
+			v.Extension = str[i:]
+			break
+		}
+		if i == start {
+			kind := KindEmpty
+			if i < len(str) && str[i] != '.' {
+				kind = KindNonNumeric
 			}
+			return nil, newParseError(ErrInvalidVersion, kind, str, numberSection(section), i)
+		}
 
-			switch section {
-			case sectionMajor:
-				v.Major = n
-			case sectionMinor:
-				v.Minor = n
-			case sectionPatch:
-				v.Patch = n
-			}
+		if section > sectionPatch {
+			v.Segments = append(v.Segments, newNumber(str[start:i]))
+		} else if overflow {
+			v.setInt(section, math.MaxInt)
+			v.Large[section] = newNumber(str[start:i])
 		} else {
-			v.Extension = string(chars)
+			v.setInt(section, n)
+		}
+
+		if i < len(str) {
+			if str[i] != '.' {
+				v.Extension = str[i:]
+				break
+			}
+			i++
 		}
+	}
+	v.PreRelease, v.Build = splitExtension(v.Extension)
+
+	return v, nil
+}
+
+// ParseBytes parses a version from a byte slice, like Parse.
+// The bytes are copied once into the Text of the version, so the slice may be reused afterwards.
+func ParseBytes(b []byte) (*Version, error) {
+	return Parse(string(b))
+}
 
-		chars = []byte{}
-		return nil
+// ParseStrict parses a version string that conforms exactly to Semantic Versioning 2.0.0.
+//
+// Unlike Parse, this function rejects a v prefix, missing minor or patch numbers, leading zeros in numeric identifiers, and empty or invalid pre-release and build metadata identifiers.
+// The error returned identifies the rule that was broken.
+//
+// See https://semver.org/#backusnaur-form-grammar-for-valid-semver-versions
+func ParseStrict(str string) (*Version, error) {
+	if len(str) == 0 {
+		return nil, newParseError(ErrInvalidVersion, KindEmpty, str, SectionMajor, 0)
+	}
+	if strings.IndexByte("vV", str[0]) > -1 {
+		return nil, newParseError(ErrPrefix, KindNone, str, SectionMajor, 0)
 	}
 
-	for i := 0; i < len(str); i++ {
-		c := str[i]
+	core := str
+	ext := ""
+	if i := strings.IndexAny(str, "-+"); i > -1 {
+		core = str[:i]
+		ext = str[i:]
+	}
 
-		if i == 0 && strings.IndexByte("vV", c) > -1 {
-			continue
+	parts := strings.Split(core, ".")
+	if len(parts) < 3 {
+		return nil, newParseError(ErrMissingComponent, KindNone, str, numberSection(len(parts)), len(core))
+	} else if len(parts) > 3 {
+		offset := len(strings.Join(parts[:3], ".")) + 1
+		return nil, newParseError(ErrExtraComponent, KindNone, str, SectionSegment, offset)
+	}
+
+	v := &Version{Extension: ext, Text: str}
+	offset := 0
+	for i, part := range parts {
+		if err := checkStrictIdentifier(part, true, str, numberSection(i), offset); err != nil {
+			return nil, err
+		}
+		if j := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' }); j > -1 {
+			return nil, newParseError(ErrInvalidCharacter, KindNone, str, numberSection(i), offset+j)
 		}
+		v.setNumber(i, part)
+		offset += len(part) + 1
+	}
 
-		if section < sectionExtension {
-			if strings.IndexByte("0123456789", c) > -1 {
-				chars = append(chars, c)
-			} else {
-				if err := commit(); err != nil {
-					return nil, err
-				}
-				if c == '.' {
-					section++
-				} else {
-					section = sectionExtension
-					chars = append(chars, c)
-				}
-			}
-		} else {
-			chars = append(chars, c)
+	preRelease, build := "", ""
+	if i := strings.IndexByte(ext, '+'); i > -1 {
+		preRelease = ext[:i]
+		build = ext[i+1:]
+		if err := checkStrictIdentifiers(build, false, str, len(core)+i+1); err != nil {
+			return nil, err
 		}
+	} else {
+		preRelease = ext
 	}
-	if len(chars) > 0 {
-		if err := commit(); err != nil {
+	if preRelease != "" {
+		if err := checkStrictIdentifiers(preRelease[1:], true, str, len(core)+1); err != nil {
 			return nil, err
 		}
 	}
 
+	v.PreRelease, v.Build = splitExtension(ext)
+
 	return v, nil
 }
+
+// checkStrictIdentifier validates a single identifier found at the given offset in str.
+// If numeric is true, a numeric identifier with a leading zero is rejected.
+func checkStrictIdentifier(id string, numeric bool, str string, section Section, offset int) error {
+	if len(id) == 0 {
+		return newParseError(ErrEmptyIdentifier, KindNone, str, section, offset)
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-') {
+			return newParseError(ErrInvalidCharacter, KindNone, str, section, offset+i)
+		}
+	}
+	if numeric && len(id) > 1 && id[0] == '0' && isNumeric(id) {
+		return newParseError(ErrLeadingZero, KindNone, str, section, offset)
+	}
+	return nil
+}
+
+// checkStrictIdentifiers validates a dot-separated list of extension identifiers found at the given offset in str.
+func checkStrictIdentifiers(ids string, numeric bool, str string, offset int) error {
+	for _, id := range strings.Split(ids, ".") {
+		if err := checkStrictIdentifier(id, numeric, str, SectionExtension, offset); err != nil {
+			return err
+		}
+		offset += len(id) + 1
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package version
 
 import (
 	"errors"
+	"math"
+	"reflect"
 	"testing"
 )
 
@@ -22,10 +24,24 @@ func TestVersion_Parse(t *testing.T) {
 		{Input: "v1.2.3", Expected: Version{Major: 1, Minor: 2, Patch: 3, Text: "v1.2.3"}},
 		{Input: "v1", Expected: Version{Major: 1, Text: "v1"}},
 		{Input: "v2.31", Expected: Version{Major: 2, Minor: 31, Text: "v2.31"}},
-		{Input: "v1.2.0a", Expected: Version{Major: 1, Minor: 2, Extension: "a", Text: "v1.2.0a"}},
-		{Input: "v1.2a", Expected: Version{Major: 1, Minor: 2, Extension: "a", Text: "v1.2a"}},
-		{Input: "v1-alpha2", Expected: Version{Major: 1, Extension: "-alpha2", Text: "v1-alpha2"}},
+		{Input: "v1.2.0a", Expected: Version{Major: 1, Minor: 2, Extension: "a", PreRelease: []Identifier{"a"}, Text: "v1.2.0a"}},
+		{Input: "v1.2a", Expected: Version{Major: 1, Minor: 2, Extension: "a", PreRelease: []Identifier{"a"}, Text: "v1.2a"}},
+		{Input: "v1-alpha2", Expected: Version{Major: 1, Extension: "-alpha2", PreRelease: []Identifier{"alpha2"}, Text: "v1-alpha2"}},
+		{Input: "1.2.3-rc.1+build.5", Expected: Version{Major: 1, Minor: 2, Patch: 3, Extension: "-rc.1+build.5", PreRelease: []Identifier{"rc", "1"}, Build: []Identifier{"build", "5"}, Text: "1.2.3-rc.1+build.5"}},
+		{Input: "1.2.3+build", Expected: Version{Major: 1, Minor: 2, Patch: 3, Extension: "+build", Build: []Identifier{"build"}, Text: "1.2.3+build"}},
+		{Input: "1.2.99999999999999999999", Expected: Version{Major: 1, Minor: 2, Patch: math.MaxInt, Large: [3]Number{2: "99999999999999999999"}, Text: "1.2.99999999999999999999"}},
+		{Input: "v0001.20231018123456789012345", Expected: Version{Major: 1, Minor: math.MaxInt, Large: [3]Number{1: "20231018123456789012345"}, Text: "v0001.20231018123456789012345"}},
+		{Input: "1.2.3.4", Expected: Version{Major: 1, Minor: 2, Patch: 3, Segments: []Number{"4"}, Text: "1.2.3.4"}},
+		{Input: "10.0.19041.3636-beta", Expected: Version{Major: 10, Patch: 19041, Segments: []Number{"3636"}, Extension: "-beta", PreRelease: []Identifier{"beta"}, Text: "10.0.19041.3636-beta"}},
+		{Input: "1.2.3.4.05", Expected: Version{Major: 1, Minor: 2, Patch: 3, Segments: []Number{"4", "5"}, Text: "1.2.3.4.05"}},
+		{Input: "1.0.0.Final", Expected: Version{Major: 1, Extension: "Final", PreRelease: []Identifier{"Final"}, Text: "1.0.0.Final"}},
+		{Input: "1.2.3.RELEASE", Expected: Version{Major: 1, Minor: 2, Patch: 3, Extension: "RELEASE", PreRelease: []Identifier{"RELEASE"}, Text: "1.2.3.RELEASE"}},
+		{Input: "2.3.4.GA", Expected: Version{Major: 2, Minor: 3, Patch: 4, Extension: "GA", PreRelease: []Identifier{"GA"}, Text: "2.3.4.GA"}},
+		{Input: "1.2.3.4.RELEASE", Expected: Version{Major: 1, Minor: 2, Patch: 3, Segments: []Number{"4"}, Extension: "RELEASE", PreRelease: []Identifier{"RELEASE"}, Text: "1.2.3.4.RELEASE"}},
+		{Input: "1.2.Final", Err: ErrInvalidVersion},
 		{Input: "invalid version", Err: ErrInvalidVersion},
+		{Input: "1.2.3..4", Err: ErrInvalidVersion},
+		{Input: "v", Expected: Version{Text: "v"}},
 		{Input: "v.01", Err: ErrInvalidVersion},
 		{Input: "v-any", Err: ErrInvalidVersion},
 	}
@@ -43,10 +59,108 @@ func TestVersion_Parse(t *testing.T) {
 			}
 		} else if err != nil {
 			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
-		} else if actual.Major != testCase.Expected.Major || actual.Minor != testCase.Expected.Minor || actual.Patch != testCase.Expected.Patch || actual.Text != testCase.Expected.Text {
+		} else if actual.Major != testCase.Expected.Major || actual.Minor != testCase.Expected.Minor || actual.Patch != testCase.Expected.Patch || actual.Extension != testCase.Expected.Extension || actual.Large != testCase.Expected.Large || actual.Text != testCase.Expected.Text {
 			t.Errorf("test %d failed (expected %v, actual %v)", i, testCase.Expected, actual)
+		} else if !reflect.DeepEqual(actual.Segments, testCase.Expected.Segments) {
+			t.Errorf("test %d failed (expected segments %v, actual %v)", i, testCase.Expected.Segments, actual.Segments)
+		} else if !reflect.DeepEqual(actual.PreRelease, testCase.Expected.PreRelease) || !reflect.DeepEqual(actual.Build, testCase.Expected.Build) {
+			t.Errorf("test %d failed (expected identifiers %v %v, actual %v %v)", i, testCase.Expected.PreRelease, testCase.Expected.Build, actual.PreRelease, actual.Build)
 		} else {
 			t.Logf("test %d passed with %v\n", i, actual)
 		}
 	}
 }
+
+func TestVersion_ParseStrict(t *testing.T) {
+	type TestCase struct {
+		Input    string
+		Expected Version
+		Err      error
+	}
+
+	testCases := []TestCase{
+		{Input: "0.0.0", Expected: Version{Text: "0.0.0"}},
+		{Input: "10.20.30", Expected: Version{Major: 10, Minor: 20, Patch: 30, Text: "10.20.30"}},
+		{Input: "1.0.0-alpha", Expected: Version{Major: 1, Extension: "-alpha", PreRelease: []Identifier{"alpha"}, Text: "1.0.0-alpha"}},
+		{Input: "1.0.0-0.3.7", Expected: Version{Major: 1, Extension: "-0.3.7", PreRelease: []Identifier{"0", "3", "7"}, Text: "1.0.0-0.3.7"}},
+		{Input: "1.0.0-x-y-z.--", Expected: Version{Major: 1, Extension: "-x-y-z.--", PreRelease: []Identifier{"x-y-z", "--"}, Text: "1.0.0-x-y-z.--"}},
+		{Input: "1.0.0+001", Expected: Version{Major: 1, Extension: "+001", Build: []Identifier{"001"}, Text: "1.0.0+001"}},
+		{Input: "1.0.0-rc.1+build.5", Expected: Version{Major: 1, Extension: "-rc.1+build.5", PreRelease: []Identifier{"rc", "1"}, Build: []Identifier{"build", "5"}, Text: "1.0.0-rc.1+build.5"}},
+		{Input: "1.0.0-alpha0.valid", Expected: Version{Major: 1, Extension: "-alpha0.valid", PreRelease: []Identifier{"alpha0", "valid"}, Text: "1.0.0-alpha0.valid"}},
+		{Input: "99999999999999999999.0.0", Expected: Version{Major: math.MaxInt, Large: [3]Number{"99999999999999999999"}, Text: "99999999999999999999.0.0"}},
+		{Input: "", Err: ErrInvalidVersion},
+		{Input: "v1.2.3", Err: ErrPrefix},
+		{Input: "1", Err: ErrMissingComponent},
+		{Input: "1.2", Err: ErrMissingComponent},
+		{Input: "1.2.3.4", Err: ErrExtraComponent},
+		{Input: "01.2.3", Err: ErrLeadingZero},
+		{Input: "1.02.3", Err: ErrLeadingZero},
+		{Input: "1.2.03", Err: ErrLeadingZero},
+		{Input: "1.2.3-01", Err: ErrLeadingZero},
+		{Input: "1..3", Err: ErrEmptyIdentifier},
+		{Input: "1.2.3-", Err: ErrEmptyIdentifier},
+		{Input: "1.2.3-rc..1", Err: ErrEmptyIdentifier},
+		{Input: "1.2.3+", Err: ErrEmptyIdentifier},
+		{Input: "1.2.3a", Err: ErrInvalidCharacter},
+		{Input: "1.2.3-rc_1", Err: ErrInvalidCharacter},
+		{Input: "1.2.3+build+5", Err: ErrInvalidCharacter},
+		{Input: "1.2.3-αlpha", Err: ErrInvalidCharacter},
+	}
+
+	for i, testCase := range testCases {
+		actual, err := ParseStrict(testCase.Input)
+
+		if testCase.Err != nil {
+			if err == nil {
+				t.Errorf("test %d failed (expected error %s, actual nil)", i, testCase.Err)
+			} else if !errors.Is(err, testCase.Err) {
+				t.Errorf("test %d failed (expected error %s, actual error %s)", i, testCase.Err, err)
+			} else {
+				t.Logf("test %d passed with error %s for %q\n", i, err, testCase.Input)
+			}
+		} else if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+		} else if actual.Major != testCase.Expected.Major || actual.Minor != testCase.Expected.Minor || actual.Patch != testCase.Expected.Patch || actual.Extension != testCase.Expected.Extension || actual.Large != testCase.Expected.Large || actual.Text != testCase.Expected.Text {
+			t.Errorf("test %d failed (expected %v, actual %v)", i, testCase.Expected, actual)
+		} else if !reflect.DeepEqual(actual.PreRelease, testCase.Expected.PreRelease) || !reflect.DeepEqual(actual.Build, testCase.Expected.Build) {
+			t.Errorf("test %d failed (expected identifiers %v %v, actual %v %v)", i, testCase.Expected.PreRelease, testCase.Expected.Build, actual.PreRelease, actual.Build)
+		} else {
+			t.Logf("test %d passed with %v\n", i, actual)
+		}
+	}
+}
+
+var benchmarkVersions = []string{
+	"1.2.3",
+	"v10.20.30",
+	"1.0.0-rc.1+build.5",
+	"10.0.19041.3636",
+	"1.2.99999999999999999999",
+}
+
+func BenchmarkParse(b *testing.B) {
+	for _, str := range benchmarkVersions {
+		b.Run(str, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := Parse(str); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkParseBytes(b *testing.B) {
+	for _, str := range benchmarkVersions {
+		data := []byte(str)
+		b.Run(str, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := ParseBytes(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package version
+
+import (
+	"regexp"
+	"strings"
+)
+
+// pep440Pattern matches any version permitted by PEP 440, including alternative spellings that normalize to the canonical form.
+// See https://packaging.python.org/en/latest/specifications/version-specifiers/#appendix-parsing-version-strings-with-regular-expressions
+var pep440Pattern = regexp.MustCompile(`(?i)^\s*v?` +
+	`(?:(?P<epoch>[0-9]+)!)?` +
+	`(?P<release>[0-9]+(?:\.[0-9]+)*)` +
+	`(?:[-_.]?(?P<pre_l>alpha|a|beta|b|preview|pre|c|rc)[-_.]?(?P<pre_n>[0-9]+)?)?` +
+	`(?:-(?P<post_n1>[0-9]+)|[-_.]?(?P<post_l>post|rev|r)[-_.]?(?P<post_n2>[0-9]+)?)?` +
+	`(?:[-_.]?(?P<dev_l>dev)[-_.]?(?P<dev_n>[0-9]+)?)?` +
+	`(?:\+(?P<local>[a-z0-9]+(?:[-_.][a-z0-9]+)*))?` +
+	`\s*$`)
+
+// PEP440 is a Python package version, as described by PEP 440.
+// See https://packaging.python.org/en/latest/specifications/version-specifiers/
+type PEP440 struct {
+	Epoch   Number   // Epoch, such as 1 in 1!2.0.
+	Release []Number // Release segments, such as [1 2 3] in 1.2.3.
+
+	Phase string // Pre-release phase: a, b or rc, or empty if this is not a pre-release.
+	Pre   Number // Pre-release number, such as 1 in 1.2.3rc1.
+
+	IsPost bool   // Whether this is a post-release.
+	Post   Number // Post-release number, such as 1 in 1.2.post1.
+
+	IsDev bool   // Whether this is a development release.
+	Dev   Number // Development release number, such as 4 in 1.2.3.dev4.
+
+	Local []string // Local version label segments, such as [local 5] in 1.2+local.5.
+
+	Text string // Original version string, if this version was created via the ParsePEP440 function.
+}
+
+// MustParsePEP440 parses a PEP 440 version, and panics if it is invalid.
+func MustParsePEP440(str string) *PEP440 {
+	v, err := ParsePEP440(str)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// ParsePEP440 parses a PEP 440 version.
+//
+// Alternative spellings permitted by PEP 440 are accepted and normalized, so 1.0-ALPHA.1 is read as 1.0a1, 1.0-1 as 1.0.post1, and 1.0.dev as 1.0.dev0.
+func ParsePEP440(str string) (*PEP440, error) {
+	m := pep440Pattern.FindStringSubmatch(str)
+	if m == nil {
+		return nil, newError(ErrInvalidPEP440, str)
+	}
+	group := func(name string) string {
+		return m[pep440Pattern.SubexpIndex(name)]
+	}
+
+	v := &PEP440{Epoch: newNumber(group("epoch")), Text: str}
+	for _, n := range strings.Split(group("release"), ".") {
+		v.Release = append(v.Release, newNumber(n))
+	}
+
+	switch strings.ToLower(group("pre_l")) {
+	case "":
+	case "a", "alpha":
+		v.Phase = "a"
+	case "b", "beta":
+		v.Phase = "b"
+	default:
+		v.Phase = "rc"
+	}
+	v.Pre = newNumber(group("pre_n"))
+
+	if group("post_n1") != "" || group("post_l") != "" {
+		v.IsPost = true
+		v.Post = newNumber(group("post_n1") + group("post_n2"))
+	}
+	if group("dev_l") != "" {
+		v.IsDev = true
+		v.Dev = newNumber(group("dev_n"))
+	}
+	if local := group("local"); local != "" {
+		v.Local = strings.FieldsFunc(strings.ToLower(local), func(r rune) bool {
+			return r == '-' || r == '_' || r == '.'
+		})
+	}
+
+	return v, nil
+}
+
+// Compare this version (a) with another version (b).
+// This function returns -1 if a is less than b, 1 if a is greater than b, or 0 if a is equal to b.
+//
+// Versions are ordered by epoch, release, pre-release, post-release, development release and local version label, as described by PEP 440.
+// Release segments are compared as though padded with zeros, so 1.2 is equal to 1.2.0.
+// A development release comes before any pre-release of the same version, so 1.0.dev1 is less than 1.0a1.
+//
+// See https://packaging.python.org/en/latest/specifications/version-specifiers/#summary-of-permitted-suffixes-and-relative-ordering
+func (a *PEP440) Compare(b *PEP440) int {
+	if a == nil {
+		if b == nil {
+			return 0
+		}
+		return -1
+	} else if b == nil {
+		return 1
+	}
+
+	if cmp := a.comparePublic(b); cmp != 0 {
+		return cmp
+	}
+	return compareLocal(a.Local, b.Local)
+}
+
+// Equal determines whether this version (a) is equal to another version (b).
+func (a *PEP440) Equal(b *PEP440) bool {
+	return a.Compare(b) == 0
+}
+
+// IsPreRelease determines whether the version is a pre-release or development release.
+func (v *PEP440) IsPreRelease() bool {
+	return v != nil && (v.Phase != "" || v.IsDev)
+}
+
+// Less determines whether this version (a) is less than another version (b).
+func (a *PEP440) Less(b *PEP440) bool {
+	return a.Compare(b) < 0
+}
+
+// Match tests the version against a constraint, such as PEP440Specifiers.
+// If the constraint is nil, this function returns true.
+func (v *PEP440) Match(m MatcherOf[*PEP440]) bool {
+	if v == nil {
+		return false
+	}
+	if m == nil {
+		return true
+	}
+	return m.Match(v)
+}
+
+// Public returns a copy of the version without its local version label.
+func (v *PEP440) Public() *PEP440 {
+	if v == nil {
+		return nil
+	}
+
+	p := *v
+	p.Local = nil
+	p.Text = ""
+	return &p
+}
+
+// String returns the normalized form of the version, such as 1!2.0rc1.post2.dev3+local.5.
+func (v *PEP440) String() string {
+	if v == nil {
+		return ""
+	}
+
+	str := strings.Builder{}
+	if !v.Epoch.isZero() {
+		str.WriteString(v.Epoch.String() + "!")
+	}
+	for i, n := range v.Release {
+		if i > 0 {
+			str.WriteByte('.')
+		}
+		str.WriteString(n.String())
+	}
+	if v.Phase != "" {
+		str.WriteString(v.Phase + v.Pre.String())
+	}
+	if v.IsPost {
+		str.WriteString(".post" + v.Post.String())
+	}
+	if v.IsDev {
+		str.WriteString(".dev" + v.Dev.String())
+	}
+	if len(v.Local) > 0 {
+		str.WriteString("+" + strings.Join(v.Local, "."))
+	}
+	return str.String()
+}
+
+// base returns the epoch and release of the version only, such as 1.2 for 1.2rc1.post2.
+func (v *PEP440) base() *PEP440 {
+	return &PEP440{Epoch: v.Epoch, Release: v.Release}
+}
+
+// comparePublic compares two versions, ignoring their local version labels.
+func (a *PEP440) comparePublic(b *PEP440) int {
+	if cmp := a.Epoch.Compare(b.Epoch); cmp != 0 {
+		return cmp
+	}
+	for i := 0; i < len(a.Release) || i < len(b.Release); i++ {
+		var an, bn Number
+		if i < len(a.Release) {
+			an = a.Release[i]
+		}
+		if i < len(b.Release) {
+			bn = b.Release[i]
+		}
+		if cmp := an.Compare(bn); cmp != 0 {
+			return cmp
+		}
+	}
+
+	if cmp := a.phaseRank() - b.phaseRank(); cmp != 0 {
+		return sign(cmp)
+	}
+	if cmp := a.Pre.Compare(b.Pre); a.Phase != "" && cmp != 0 {
+		return cmp
+	}
+
+	if a.IsPost != b.IsPost {
+		if a.IsPost {
+			return 1
+		}
+		return -1
+	}
+	if cmp := a.Post.Compare(b.Post); a.IsPost && cmp != 0 {
+		return cmp
+	}
+
+	if a.IsDev != b.IsDev {
+		if a.IsDev {
+			return -1
+		}
+		return 1
+	}
+	if a.IsDev {
+		return a.Dev.Compare(b.Dev)
+	}
+	return 0
+}
+
+// phaseRank returns the position of the pre-release phase of the version in order of precedence.
+// A development release of a final version, such as 1.0.dev1, comes before all pre-releases.
+func (v *PEP440) phaseRank() int {
+	switch v.Phase {
+	case "a":
+		return 1
+	case "b":
+		return 2
+	case "rc":
+		return 3
+	}
+	if v.IsDev && !v.IsPost {
+		return 0
+	}
+	return 4
+}
+
+// compareLocal compares two local version labels.
+// Numeric segments are greater than alphanumeric segments, and a label that extends another is greater than it.
+func compareLocal(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		an, bn := isNumeric(a[i]), isNumeric(b[i])
+		switch {
+		case an && bn:
+			if cmp := compareDigits(a[i], b[i]); cmp != 0 {
+				return cmp
+			}
+		case an:
+			return 1
+		case bn:
+			return -1
+		default:
+			if cmp := strings.Compare(a[i], b[i]); cmp != 0 {
+				return cmp
+			}
+		}
+	}
+	return sign(len(a) - len(b))
+}
+
+// sign returns -1, 0 or 1 according to the sign of n.
+func sign(n int) int {
+	if n < 0 {
+		return -1
+	} else if n > 0 {
+		return 1
+	}
+	return 0
+}
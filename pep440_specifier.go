@@ -0,0 +1,215 @@
+package version
+
+import "strings"
+
+// PEP440Specifier is a single clause of a PEP 440 version specifier, such as >=1.2 or ==1.2.*.
+type PEP440Specifier struct {
+	Operator string  // Comparison operator: ~=, ==, !=, <=, >=, <, > or ===.
+	Version  *PEP440 // Version to compare with. This is nil for the === operator, which compares Text only.
+	Wildcard bool    // Whether the version ends with .*, which is allowed for == and != only.
+	Text     string  // Version as written in the specifier.
+}
+
+// PEP440Specifiers is a set of PEP 440 version specifier clauses, such as >=1.2,!=1.3.*,<2.
+// A version matches the set if it matches every clause.
+//
+// As in pip, pre-releases and development releases match only if a clause mentions one, such as >=1.0rc1.
+//
+// See https://packaging.python.org/en/latest/specifications/version-specifiers/#version-specifiers
+type PEP440Specifiers []PEP440Specifier
+
+// ParsePEP440Specifiers parses a comma-separated list of PEP 440 version specifier clauses.
+// An empty string is an empty set, which matches any version except pre-releases.
+func ParsePEP440Specifiers(str string) (PEP440Specifiers, error) {
+	specs := PEP440Specifiers{}
+	if strings.TrimSpace(str) == "" {
+		return specs, nil
+	}
+
+	for _, clause := range strings.Split(str, ",") {
+		s, ok := parsePEP440Specifier(strings.TrimSpace(clause))
+		if !ok {
+			return nil, newError(ErrInvalidPEP440Specifier, str)
+		}
+		specs = append(specs, s)
+	}
+	return specs, nil
+}
+
+// Match tests a version against every clause in the set.
+func (specs PEP440Specifiers) Match(v *PEP440) bool {
+	if v == nil {
+		return false
+	}
+
+	if v.IsPreRelease() && !specs.preReleases() {
+		return false
+	}
+	in := IntersectionOf[*PEP440]{}
+	for _, s := range specs {
+		in = append(in, s.matcher())
+	}
+	return in.Match(v)
+}
+
+func (specs PEP440Specifiers) String() string {
+	strs := []string{}
+	for _, s := range specs {
+		strs = append(strs, s.String())
+	}
+	return strings.Join(strs, ",")
+}
+
+// Match tests a version against the clause.
+// Unlike PEP440Specifiers, a single clause does not exclude pre-releases.
+func (s PEP440Specifier) Match(v *PEP440) bool {
+	if v == nil {
+		return false
+	}
+	return s.matcher().Match(v)
+}
+
+func (s PEP440Specifier) String() string {
+	if s.Version == nil {
+		return s.Operator + s.Text
+	}
+	if s.Wildcard {
+		return s.Operator + s.Version.String() + ".*"
+	}
+	return s.Operator + s.Version.String()
+}
+
+// equal returns a constraint matching the version of the clause, as used by the == and != operators.
+// The local version label is ignored unless the clause has one, and a wildcard matches any version with the same release prefix.
+func (s PEP440Specifier) equal() MatcherOf[*PEP440] {
+	if s.Wildcard {
+		// ==1.2.* is equivalent to >=1.2.dev0 <1.3.dev0
+		first := &PEP440{Epoch: s.Version.Epoch, Release: s.Version.Release, IsDev: true, Dev: newNumber("0")}
+		return &ConstraintOf[*PEP440]{Gte: first, Lt: s.Version.nextPrefix()}
+	}
+
+	c := &ConstraintOf[*PEP440]{Gte: s.Version, Lte: s.Version}
+	if len(s.Version.Local) == 0 {
+		return pep440Public(c)
+	}
+	return c
+}
+
+// matcher returns the clause as a constraint.
+// Comparisons are made with ranges, except for the pre-releases, post-releases and local versions that PEP 440 excludes from some of them.
+func (s PEP440Specifier) matcher() MatcherOf[*PEP440] {
+	switch s.Operator {
+	case "===":
+		// Arbitrary equality compares the version as written, without normalizing it
+		return pep440Func(func(v *PEP440) bool {
+			text := v.Text
+			if text == "" {
+				text = v.String()
+			}
+			return strings.EqualFold(text, s.Text)
+		})
+	case "==":
+		return s.equal()
+	case "!=":
+		return ExclusionOf[*PEP440]{Matcher: s.equal()}
+	case "~=":
+		// ~=1.4.5 is equivalent to >=1.4.5, ==1.4.*
+		prefix := &PEP440{Epoch: s.Version.Epoch, Release: s.Version.Release[:len(s.Version.Release)-1]}
+		return &ConstraintOf[*PEP440]{Gte: s.Version, Lt: prefix.nextPrefix()}
+	case "<=":
+		return pep440Public(&ConstraintOf[*PEP440]{Lte: s.Version})
+	case ">=":
+		return &ConstraintOf[*PEP440]{Gte: s.Version}
+	case "<":
+		// Pre-releases of the same release are excluded unless the clause is a pre-release, such as 2.0rc1 for <2.0
+		excluded := pep440Func(func(v *PEP440) bool {
+			return !s.Version.IsPreRelease() && v.IsPreRelease() && v.base().Equal(s.Version.base())
+		})
+		return IntersectionOf[*PEP440]{&ConstraintOf[*PEP440]{Lt: s.Version}, ExclusionOf[*PEP440]{Matcher: excluded}}
+	case ">":
+		// Post-releases of the same release are excluded unless the clause is a post-release, such as 1.7.post2 for >1.7, and local versions of the same release are always excluded
+		excluded := pep440Func(func(v *PEP440) bool {
+			return (!s.Version.IsPost && v.IsPost || len(v.Local) > 0) && v.base().Equal(s.Version.base())
+		})
+		return IntersectionOf[*PEP440]{&ConstraintOf[*PEP440]{Gt: s.Version}, ExclusionOf[*PEP440]{Matcher: excluded}}
+	}
+	return UnionOf[*PEP440]{}
+}
+
+// preReleases determines whether any clause in the set mentions a pre-release, allowing pre-releases to match.
+func (specs PEP440Specifiers) preReleases() bool {
+	for _, s := range specs {
+		if s.Operator != "!=" && !s.Wildcard && s.Version.IsPreRelease() {
+			return true
+		}
+	}
+	return false
+}
+
+// nextPrefix returns the lowest version after every version that starts with the epoch and release of this version, such as 1.3.dev0 for 1.2.
+func (v *PEP440) nextPrefix() *PEP440 {
+	release := append([]Number{}, v.Release...)
+	release[len(release)-1] = release[len(release)-1].next()
+	return &PEP440{Epoch: v.Epoch, Release: release, IsDev: true, Dev: newNumber("0")}
+}
+
+// parsePEP440Specifier parses a single specifier clause, checking that its version is allowed with its operator.
+func parsePEP440Specifier(str string) (PEP440Specifier, bool) {
+	s := PEP440Specifier{}
+	for _, op := range []string{"~=", "===", "==", "!=", "<=", ">=", "<", ">"} {
+		if strings.HasPrefix(str, op) {
+			s.Operator = op
+			break
+		}
+	}
+	if s.Operator == "" {
+		return s, false
+	}
+
+	s.Text = strings.TrimSpace(str[len(s.Operator):])
+	if s.Text == "" {
+		return s, false
+	}
+	if s.Operator == "===" {
+		return s, !strings.ContainsAny(s.Text, " \t,;")
+	}
+
+	text := s.Text
+	if strings.HasSuffix(text, ".*") {
+		if s.Operator != "==" && s.Operator != "!=" {
+			return s, false
+		}
+		s.Wildcard = true
+		text = strings.TrimSuffix(text, ".*")
+	}
+
+	v, err := ParsePEP440(text)
+	if err != nil {
+		return s, false
+	}
+	s.Version = v
+
+	switch {
+	case s.Wildcard && (v.Phase != "" || v.IsPost || v.IsDev || len(v.Local) > 0):
+		return s, false
+	case len(v.Local) > 0 && s.Operator != "==" && s.Operator != "!=":
+		return s, false
+	case s.Operator == "~=" && len(v.Release) < 2:
+		return s, false
+	}
+	return s, true
+}
+
+// pep440Func is a constraint on PEP 440 versions that cannot be expressed as a range.
+type pep440Func func(v *PEP440) bool
+
+func (f pep440Func) Match(v *PEP440) bool {
+	return f(v)
+}
+
+// pep440Public returns a constraint that tests the public version only, ignoring any local version label.
+func pep440Public(m MatcherOf[*PEP440]) MatcherOf[*PEP440] {
+	return pep440Func(func(v *PEP440) bool {
+		return m.Match(v.Public())
+	})
+}
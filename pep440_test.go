@@ -0,0 +1,203 @@
+package version
+
+import (
+	"errors"
+	"sort"
+	"testing"
+)
+
+func TestParsePEP440(t *testing.T) {
+	type TestCase struct {
+		Input    string
+		Expected string
+		Err      error
+	}
+
+	testCases := []TestCase{
+		{Input: "1.2.3", Expected: "1.2.3"},
+		{Input: "v1.2.3rc1", Expected: "1.2.3rc1"},
+		{Input: "1.2.post1", Expected: "1.2.post1"},
+		{Input: "1!2.0", Expected: "1!2.0"},
+		{Input: "0!2.0", Expected: "2.0"},
+		{Input: "1.2.3.dev4", Expected: "1.2.3.dev4"},
+		{Input: "1.2+local.5", Expected: "1.2+local.5"},
+		{Input: "1.0-ALPHA.1", Expected: "1.0a1"},
+		{Input: "1.0.beta", Expected: "1.0b0"},
+		{Input: "1.0c2", Expected: "1.0rc2"},
+		{Input: "1.0-preview_3", Expected: "1.0rc3"},
+		{Input: "1.0-1", Expected: "1.0.post1"},
+		{Input: "1.0-r2", Expected: "1.0.post2"},
+		{Input: "1.0.rev", Expected: "1.0.post0"},
+		{Input: "1.0.dev", Expected: "1.0.dev0"},
+		{Input: "01.002rc03.post04.dev05+Ubuntu-1_Focal", Expected: "1.2rc3.post4.dev5+ubuntu.1.focal"},
+		{Input: "  1.0  ", Expected: "1.0"},
+		{Input: "", Err: ErrInvalidPEP440},
+		{Input: "1.0+", Err: ErrInvalidPEP440},
+		{Input: "1.0-beta-gamma", Err: ErrInvalidPEP440},
+		{Input: "1..0", Err: ErrInvalidPEP440},
+	}
+
+	for i, testCase := range testCases {
+		actual, err := ParsePEP440(testCase.Input)
+
+		if testCase.Err != nil {
+			if !errors.Is(err, testCase.Err) {
+				t.Errorf("test %d failed (expected error %s, actual %v)", i, testCase.Err, err)
+			} else {
+				t.Logf("test %d passed with error %s for %q\n", i, err, testCase.Input)
+			}
+		} else if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+		} else if actual.String() != testCase.Expected {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, testCase.Expected, actual)
+		} else {
+			t.Logf("test %d passed with %s", i, actual)
+		}
+	}
+}
+
+func TestPEP440_Compare(t *testing.T) {
+	// Ordering given as an example in PEP 440, extended with epochs and local versions
+	ordered := []string{
+		"1.0.dev456",
+		"1.0a1",
+		"1.0a2.dev456",
+		"1.0a12.dev456",
+		"1.0a12",
+		"1.0b1.dev456",
+		"1.0b2",
+		"1.0b2.post345.dev456",
+		"1.0b2.post345",
+		"1.0rc1.dev456",
+		"1.0rc1",
+		"1.0",
+		"1.0+abc.5",
+		"1.0+abc.7",
+		"1.0+5",
+		"1.0.post456.dev34",
+		"1.0.post456",
+		"1.0.15",
+		"1.1.dev1",
+		"1!0.1",
+	}
+
+	for i := 1; i < len(ordered); i++ {
+		a := MustParsePEP440(ordered[i-1])
+		b := MustParsePEP440(ordered[i])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("test %d failed (expected %s < %s)", i, a, b)
+		}
+	}
+
+	if cmp := MustParsePEP440("1.2").Compare(MustParsePEP440("1.2.0")); cmp != 0 {
+		t.Errorf("expected 1.2 == 1.2.0, actual %d", cmp)
+	}
+
+	list := ListOf[*PEP440]{}
+	for i := len(ordered) - 1; i >= 0; i-- {
+		list = append(list, MustParsePEP440(ordered[i]))
+	}
+	sort.Sort(list)
+	for i, v := range list {
+		if v.Text != ordered[i] {
+			t.Errorf("sort failed at position %d (expected %s, actual %s)", i, ordered[i], v)
+		}
+	}
+}
+
+func TestPEP440Specifiers_Match(t *testing.T) {
+	type TestCase struct {
+		Specifiers string
+		Match      []string
+		NoMatch    []string
+	}
+
+	testCases := []TestCase{
+		{Specifiers: "", Match: []string{"1.0", "1.0.post1", "1.0+local"}, NoMatch: []string{"1.0rc1", "1.0.dev1"}},
+		{Specifiers: "~=2.2", Match: []string{"2.2", "2.3", "2.9.1"}, NoMatch: []string{"2.1", "3.0", "2.3rc1"}},
+		{Specifiers: "~=1.4.5", Match: []string{"1.4.5", "1.4.9"}, NoMatch: []string{"1.5.0", "1.4.4"}},
+		{Specifiers: "~=2.2.post3", Match: []string{"2.2.post3", "2.9"}, NoMatch: []string{"2.2", "3.0"}},
+		{Specifiers: "==1.2.*", Match: []string{"1.2", "1.2.0", "1.2.9.post1", "1.2+local"}, NoMatch: []string{"1.3", "1.20"}},
+		{Specifiers: "==1.2.0", Match: []string{"1.2", "1.2.0+local"}, NoMatch: []string{"1.2.1", "1.2.post1"}},
+		{Specifiers: "==1.2+local", Match: []string{"1.2+local"}, NoMatch: []string{"1.2", "1.2+other"}},
+		{Specifiers: "!=1.3.*, >=1.0", Match: []string{"1.2", "1.4"}, NoMatch: []string{"1.3", "1.3.4", "0.9"}},
+		{Specifiers: "<2.0", Match: []string{"1.9"}, NoMatch: []string{"2.0", "2.0rc1"}},
+		{Specifiers: "<2.0rc2", Match: []string{"2.0rc1", "1.9"}, NoMatch: []string{"2.0rc2"}},
+		{Specifiers: ">1.7", Match: []string{"1.7.1", "1.8"}, NoMatch: []string{"1.7", "1.7.post2", "1.7+local"}},
+		{Specifiers: ">1.7.post2", Match: []string{"1.7.post3", "1.8"}, NoMatch: []string{"1.7.post2", "1.7.1a1"}},
+		{Specifiers: ">=1.0rc1", Match: []string{"1.0rc1", "1.0", "2.0b1"}, NoMatch: []string{"1.0b9"}},
+		{Specifiers: "<=1.0", Match: []string{"1.0", "1.0+local", "0.9"}, NoMatch: []string{"1.0.post1"}},
+		{Specifiers: "===1.0.0", Match: []string{"1.0.0"}, NoMatch: []string{"1.0", "1.0.0.0"}},
+		{Specifiers: "===1.0.post1", Match: []string{"1.0.POST1"}, NoMatch: []string{"v1.0.post1", "1.0-1", "1.0post1"}},
+		{Specifiers: "===1.0", NoMatch: []string{"v1.0"}},
+	}
+
+	for i, testCase := range testCases {
+		specs, err := ParsePEP440Specifiers(testCase.Specifiers)
+		if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+			continue
+		}
+
+		ok := true
+		for _, str := range testCase.Match {
+			if !MustParsePEP440(str).Match(specs) {
+				ok = false
+				t.Errorf("test %d failed (expected %s to match %s)", i, str, specs)
+			}
+		}
+		for _, str := range testCase.NoMatch {
+			if MustParsePEP440(str).Match(specs) {
+				ok = false
+				t.Errorf("test %d failed (expected %s not to match %s)", i, str, specs)
+			}
+		}
+		if ok {
+			t.Logf("test %d passed for %s", i, specs)
+		}
+	}
+}
+
+func TestParsePEP440Specifiers(t *testing.T) {
+	type TestCase struct {
+		Input    string
+		Expected string
+		Err      error
+	}
+
+	testCases := []TestCase{
+		{Input: ">= 1.0, != 1.3.*, < 2.0", Expected: ">=1.0,!=1.3.*,<2.0"},
+		{Input: "~=1.4.5RC1", Expected: "~=1.4.5rc1"},
+		{Input: "===foobar", Expected: "===foobar"},
+		{Input: "1.0", Err: ErrInvalidPEP440Specifier},
+		{Input: ">=1.0.*", Err: ErrInvalidPEP440Specifier},
+		{Input: "==1.0rc1.*", Err: ErrInvalidPEP440Specifier},
+		{Input: ">=1.0+local", Err: ErrInvalidPEP440Specifier},
+		{Input: "~=1", Err: ErrInvalidPEP440Specifier},
+		{Input: ">=1.0,", Err: ErrInvalidPEP440Specifier},
+	}
+
+	for i, testCase := range testCases {
+		actual, err := ParsePEP440Specifiers(testCase.Input)
+
+		if testCase.Err != nil {
+			if !errors.Is(err, testCase.Err) {
+				t.Errorf("test %d failed (expected error %s, actual %v)", i, testCase.Err, err)
+			} else {
+				t.Logf("test %d passed with error %s for %q\n", i, err, testCase.Input)
+			}
+		} else if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+		} else if actual.String() != testCase.Expected {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, testCase.Expected, actual)
+		} else {
+			t.Logf("test %d passed with %s", i, actual)
+		}
+	}
+
+	list := ListOf[*PEP440]{MustParsePEP440("1.0"), MustParsePEP440("1.3.1"), MustParsePEP440("1.5"), MustParsePEP440("2.0")}
+	specs, _ := ParsePEP440Specifiers(">=1.0,!=1.3.*,<2.0")
+	if matched := list.Match(specs); len(matched) != 2 || matched[1].String() != "1.5" {
+		t.Errorf("list match failed (actual %v)", matched)
+	}
+}
@@ -0,0 +1,242 @@
+package version
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RPM is an RPM package version, in the form [epoch:]version[-release], also known as an EVR.
+// See https://rpm-software-management.github.io/rpm/manual/dependencies.html#versioning
+type RPM struct {
+	Epoch   int    // Epoch, such as 1 in 1:2.30-4.el9. Zero if not given.
+	Version string // Version, such as 2.30 in 1:2.30-4.el9.
+	Release string // Release, such as 4.el9 in 1:2.30-4.el9. Empty if not given.
+
+	Text string // Original version string, if this version was created via the ParseRPM function.
+}
+
+// MustParseRPM parses an RPM version, and panics if it is invalid.
+func MustParseRPM(str string) *RPM {
+	v, err := ParseRPM(str)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// ParseRPM parses an RPM package version.
+//
+// The epoch, if given, must be a non-negative integer.
+// The release follows the last hyphen, so the version itself may not contain a hyphen.
+// Otherwise, the version and release may contain letters, digits and the characters . _ + ~ ^.
+func ParseRPM(str string) (*RPM, error) {
+	text := strings.TrimSpace(str)
+	if text == "" {
+		return nil, newParseError(ErrInvalidRPM, KindEmpty, str, SectionMajor, 0)
+	}
+
+	v := &RPM{Version: text, Text: str}
+	if i := strings.IndexByte(text, ':'); i > -1 {
+		epoch, err := strconv.Atoi(text[:i])
+		if err != nil || !isNumeric(text[:i]) {
+			return nil, newParseError(ErrInvalidRPM, KindNonNumeric, str, SectionMajor, 0)
+		}
+		v.Epoch = epoch
+		v.Version = text[i+1:]
+	}
+	if i := strings.LastIndexByte(v.Version, '-'); i > -1 {
+		v.Release = v.Version[i+1:]
+		v.Version = v.Version[:i]
+		if v.Release == "" {
+			return nil, newParseError(ErrInvalidRPM, KindEmpty, str, SectionExtension, len(str))
+		}
+	}
+	if v.Version == "" {
+		return nil, newError(ErrInvalidRPM, str)
+	}
+
+	for _, c := range []byte(v.Version + v.Release) {
+		if !isAlphanumeric(c) && strings.IndexByte("._+~^", c) < 0 {
+			return nil, newError(ErrInvalidRPM, str)
+		}
+	}
+
+	return v, nil
+}
+
+// Compare this version (a) with another version (b), following the rules of rpmvercmp.
+// This function returns -1 if a is less than b, 1 if a is greater than b, or 0 if a is equal to b.
+//
+// Epochs are compared numerically, then the versions and releases are each compared in segments of digits or letters, ignoring other characters.
+// Numeric segments are greater than alphabetic segments.
+// A tilde sorts before anything, even the end of the version, so 1.0~rc1 is less than 1.0.
+// A caret sorts after the end of the version but before anything else, so 1.0^git1 is greater than 1.0 but less than 1.0.1.
+//
+// See https://rpm-software-management.github.io/rpm/manual/dependencies.html#versioning
+func (a *RPM) Compare(b *RPM) int {
+	if a == nil {
+		if b == nil {
+			return 0
+		}
+		return -1
+	} else if b == nil {
+		return 1
+	}
+
+	if a.Epoch != b.Epoch {
+		return sign(a.Epoch - b.Epoch)
+	}
+	if cmp := compareRPMPart(a.Version, b.Version); cmp != 0 {
+		return cmp
+	}
+	return compareRPMPart(a.Release, b.Release)
+}
+
+// Equal determines whether this version (a) is equal to another version (b).
+func (a *RPM) Equal(b *RPM) bool {
+	return a.Compare(b) == 0
+}
+
+// Less determines whether this version (a) is less than another version (b).
+func (a *RPM) Less(b *RPM) bool {
+	return a.Compare(b) < 0
+}
+
+// Match tests the version against a constraint, such as a ConstraintOf.
+// If the constraint is nil, this function returns true.
+func (v *RPM) Match(m MatcherOf[*RPM]) bool {
+	if v == nil {
+		return false
+	}
+	if m == nil {
+		return true
+	}
+	return m.Match(v)
+}
+
+// Canonical returns the canonical form of the version, omitting a zero epoch.
+func (v *RPM) Canonical() string {
+	if v == nil {
+		return ""
+	}
+
+	str := v.Version
+	if v.Epoch != 0 {
+		str = strconv.Itoa(v.Epoch) + ":" + str
+	}
+	if v.Release != "" {
+		str += "-" + v.Release
+	}
+	return str
+}
+
+func (v *RPM) String() string {
+	if v == nil {
+		return ""
+	}
+
+	if v.Text != "" {
+		return v.Text
+	}
+
+	return v.Canonical()
+}
+
+// compareRPMPart compares two versions or releases, as in the rpmvercmp function of RPM.
+func compareRPMPart(a, b string) int {
+	if a == b {
+		return 0
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && !isRPMSignificant(a[i]) {
+			i++
+		}
+		for j < len(b) && !isRPMSignificant(b[j]) {
+			j++
+		}
+
+		// A tilde is less than anything, including the end of the string
+		aTilde, bTilde := i < len(a) && a[i] == '~', j < len(b) && b[j] == '~'
+		if aTilde || bTilde {
+			if !aTilde {
+				return 1
+			} else if !bTilde {
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+
+		// A caret is greater than the end of the string, but less than anything else
+		aCaret, bCaret := i < len(a) && a[i] == '^', j < len(b) && b[j] == '^'
+		if aCaret || bCaret {
+			if i >= len(a) {
+				return -1
+			} else if j >= len(b) {
+				return 1
+			} else if !aCaret {
+				return 1
+			} else if !bCaret {
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+
+		if i >= len(a) || j >= len(b) {
+			break
+		}
+
+		numeric := isDigit(a[i])
+		match := isDigit
+		if !numeric {
+			match = isLetter
+		}
+		ai, bj := i, j
+		for i < len(a) && match(a[i]) {
+			i++
+		}
+		for j < len(b) && match(b[j]) {
+			j++
+		}
+
+		// Segments of different types: numbers are greater than letters
+		if bj == j {
+			if numeric {
+				return 1
+			}
+			return -1
+		}
+
+		var cmp int
+		if numeric {
+			cmp = compareDigits(a[ai:i], b[bj:j])
+		} else {
+			cmp = strings.Compare(a[ai:i], b[bj:j])
+		}
+		if cmp != 0 {
+			return cmp
+		}
+	}
+
+	if i >= len(a) && j >= len(b) {
+		return 0
+	} else if i >= len(a) {
+		return -1
+	}
+	return 1
+}
+
+// isRPMSignificant determines whether a byte is compared by rpmvercmp, rather than being skipped as a separator.
+func isRPMSignificant(c byte) bool {
+	return isAlphanumeric(c) || c == '~' || c == '^'
+}
+
+// isLetter determines whether a byte is an ASCII letter.
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,109 @@
+package version
+
+import (
+	"errors"
+	"sort"
+	"testing"
+)
+
+func TestParseRPM(t *testing.T) {
+	type TestCase struct {
+		Input    string
+		Epoch    int
+		Version  string
+		Release  string
+		Expected string
+		Err      error
+	}
+
+	testCases := []TestCase{
+		{Input: "0:1.2.3-4.el9", Version: "1.2.3", Release: "4.el9", Expected: "1.2.3-4.el9"},
+		{Input: "1.0^git20230101", Version: "1.0^git20230101", Expected: "1.0^git20230101"},
+		{Input: "2:5.1~rc2-0.1.fc39", Epoch: 2, Version: "5.1~rc2", Release: "0.1.fc39", Expected: "2:5.1~rc2-0.1.fc39"},
+		{Input: "1.2_3+b", Version: "1.2_3+b", Expected: "1.2_3+b"},
+		{Input: "", Err: ErrInvalidRPM},
+		{Input: "x:1.0", Err: ErrInvalidRPM},
+		{Input: "-1:1.0", Err: ErrInvalidRPM},
+		{Input: "1.0-", Err: ErrInvalidRPM},
+		{Input: "-1", Err: ErrInvalidRPM},
+		{Input: "1.0-1-2", Err: ErrInvalidRPM},
+		{Input: "1.0:1", Err: ErrInvalidRPM},
+		{Input: "1.0 1", Err: ErrInvalidRPM},
+	}
+
+	for i, testCase := range testCases {
+		actual, err := ParseRPM(testCase.Input)
+
+		if testCase.Err != nil {
+			if !errors.Is(err, testCase.Err) {
+				t.Errorf("test %d failed (expected error %s, actual %v)", i, testCase.Err, err)
+			} else {
+				t.Logf("test %d passed with error %s for %q\n", i, err, testCase.Input)
+			}
+		} else if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+		} else if actual.Epoch != testCase.Epoch || actual.Version != testCase.Version || actual.Release != testCase.Release {
+			t.Errorf("test %d failed (expected %d:%s-%s, actual %d:%s-%s)", i, testCase.Epoch, testCase.Version, testCase.Release, actual.Epoch, actual.Version, actual.Release)
+		} else if actual.Canonical() != testCase.Expected {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, testCase.Expected, actual.Canonical())
+		} else {
+			t.Logf("test %d passed with %s", i, actual)
+		}
+	}
+}
+
+func TestRPM_Compare(t *testing.T) {
+	// Ordering consistent with the rpmvercmp test suite
+	ordered := []string{
+		"1.0~~",
+		"1.0~rc1",
+		"1.0~rc1^git1",
+		"1.0~rc2",
+		"1.0",
+		"1.0^",
+		"1.0^git1",
+		"1.0^git2",
+		"1.0a",
+		"1.0.1",
+		"1.0.1-1",
+		"1.0.1-1.el9",
+		"1.0.1-2",
+		"1.2.3-4.el9",
+		"1.10",
+		"2.0",
+		"2.0a",
+		"1:0.1",
+		"1:2.0-1",
+	}
+
+	for i := 1; i < len(ordered); i++ {
+		a := MustParseRPM(ordered[i-1])
+		b := MustParseRPM(ordered[i])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("test %d failed (expected %s < %s)", i, a, b)
+		}
+	}
+
+	equal := [][2]string{{"1.0", "0:1.0"}, {"1.01", "1.1"}, {"1.0.a", "1.0a"}, {"1_0", "1.0"}, {"1.2.3-4.el9", "0:1.2.3-4.el9"}}
+	for _, pair := range equal {
+		if cmp := MustParseRPM(pair[0]).Compare(MustParseRPM(pair[1])); cmp != 0 {
+			t.Errorf("expected %s == %s, actual %d", pair[0], pair[1], cmp)
+		}
+	}
+
+	list := ListOf[*RPM]{}
+	for i := len(ordered) - 1; i >= 0; i-- {
+		list = append(list, MustParseRPM(ordered[i]))
+	}
+	sort.Sort(list)
+	for i, v := range list {
+		if v.Text != ordered[i] {
+			t.Errorf("sort failed at position %d (expected %s, actual %s)", i, ordered[i], v)
+		}
+	}
+
+	r := &ConstraintOf[*RPM]{Gte: MustParseRPM("1.0"), Lt: MustParseRPM("1.0.1")}
+	if matched := list.Match(r); len(matched) != 5 || matched[0].String() != "1.0" || matched[4].String() != "1.0a" {
+		t.Errorf("range match failed (actual %v)", matched)
+	}
+}
@@ -0,0 +1,244 @@
+package version
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Scheme is a versioning scheme, such as Semantic Versioning or PEP 440, for versions of type V.
+//
+// The built-in schemes are registered by name, and can be looked up with LookupScheme.
+// Other schemes, such as a CalVerFormat, can be added with RegisterScheme.
+type Scheme[V any] interface {
+	// Parse parses a version string.
+	Parse(str string) (V, error)
+
+	// Compare a version (a) with another version (b).
+	// This function returns -1 if a is less than b, 1 if a is greater than b, or 0 if a is equal to b.
+	Compare(a, b V) int
+
+	// Format returns the canonical string form of a version.
+	Format(v V) string
+
+	// Validate checks a version string, returning the same error as Parse.
+	Validate(str string) error
+}
+
+// Built-in versioning schemes.
+// SemVerScheme accepts versions that conform exactly to Semantic Versioning, as ParseStrict does, and LenientScheme accepts any version that Parse does.
+var (
+	SemVerScheme  = NewScheme(ParseStrict, (*Version).SemanticString)
+	LenientScheme = NewScheme(Parse, (*Version).SemanticString)
+	ModuleScheme  = NewScheme(ParseModule, (*ModuleVersion).Canonical)
+	PEP440Scheme  = NewScheme(ParsePEP440, (*PEP440).String)
+	DebianScheme  = NewScheme(ParseDebian, (*Debian).Canonical)
+	RPMScheme     = NewScheme(ParseRPM, (*RPM).Canonical)
+	MavenScheme   = NewScheme(ParseMaven, (*Maven).Canonical)
+)
+
+// schemeEntry is a registered scheme, with a copy that accepts versions of any type.
+type schemeEntry struct {
+	scheme any
+	erased Scheme[any]
+}
+
+var (
+	schemesMu sync.RWMutex
+	schemes   = map[string]schemeEntry{
+		"debian":  newSchemeEntry(DebianScheme),
+		"go":      newSchemeEntry(ModuleScheme),
+		"lenient": newSchemeEntry(LenientScheme),
+		"maven":   newSchemeEntry(MavenScheme),
+		"pep440":  newSchemeEntry(PEP440Scheme),
+		"rpm":     newSchemeEntry(RPMScheme),
+		"semver":  newSchemeEntry(SemVerScheme),
+	}
+)
+
+// NewScheme creates a Scheme from a parse function and a format function.
+// Versions are compared with their own Compare method.
+func NewScheme[V Comparable[V]](parse func(string) (V, error), format func(V) string) Scheme[V] {
+	return funcScheme[V]{parse: parse, format: format}
+}
+
+// LookupScheme returns the scheme registered with a name, such as semver, lenient, go, pep440, debian, rpm or maven.
+//
+// The scheme accepts and returns versions of any type, so that schemes can be used interchangeably, such as when the name of a scheme is read from configuration.
+// Its Compare and Format methods expect versions returned by its own Parse method.
+// To use the specific type of version of a scheme, use LookupSchemeOf instead.
+func LookupScheme(name string) (Scheme[any], bool) {
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
+
+	e, ok := schemes[name]
+	return e.erased, ok
+}
+
+// LookupSchemeOf returns the scheme registered with a name, if its versions are of type V.
+func LookupSchemeOf[V any](name string) (Scheme[V], bool) {
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
+
+	s, ok := schemes[name].scheme.(Scheme[V])
+	return s, ok
+}
+
+// RegisterScheme makes a scheme available by name, such as:
+//
+//	version.RegisterScheme("calver", version.MustParseCalVerFormat("YYYY.0M.MICRO"))
+//
+// If a scheme is already registered with the name, this function panics.
+func RegisterScheme[V any](name string, s Scheme[V]) {
+	schemesMu.Lock()
+	defer schemesMu.Unlock()
+
+	if _, ok := schemes[name]; ok {
+		panic("version: scheme " + name + " is already registered")
+	}
+	schemes[name] = newSchemeEntry(s)
+}
+
+// unregisterScheme removes the scheme registered with a name, if any.
+func unregisterScheme(name string) {
+	schemesMu.Lock()
+	defer schemesMu.Unlock()
+
+	delete(schemes, name)
+}
+
+// SchemeNames returns the names of all registered schemes, in lexical order.
+func SchemeNames() []string {
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
+
+	names := []string{}
+	for name := range schemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseRangeOf parses a version range expression for any scheme, such as >=1.0 <2.0 || >=3.0.
+//
+// The syntax is the same as ParseConstraintOf, so a range is made up of comparisons separated by spaces or commas, using the operators >, >=, <, <=, = and !=.
+// A version without an operator matches that version only.
+//
+// Versions are parsed and compared according to the scheme, so the same expression can select versions of any format.
+func ParseRangeOf[V any](s Scheme[V], str string) (MatcherOf[V], error) {
+	m, err := ParseConstraintOf(func(str string) (schemeVersion[V], error) {
+		v, err := s.Parse(str)
+		return schemeVersion[V]{scheme: s, version: v}, err
+	}, str)
+	if err != nil {
+		return nil, err
+	}
+	return schemeMatcher[V]{scheme: s, matcher: m}, nil
+}
+
+// SortOf sorts versions in ascending order, according to a scheme.
+// The sort is stable, so versions that are equal keep their original order.
+func SortOf[V any](s Scheme[V], versions []V) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return s.Compare(versions[i], versions[j]) < 0
+	})
+}
+
+func newSchemeEntry[V any](s Scheme[V]) schemeEntry {
+	return schemeEntry{scheme: s, erased: anyScheme[V]{scheme: s}}
+}
+
+// anyScheme adapts a scheme to accept and return versions of any type.
+type anyScheme[V any] struct {
+	scheme Scheme[V]
+}
+
+// Compare a version (a) with another version (b).
+// If either is not of the type of the scheme, this function panics.
+func (s anyScheme[V]) Compare(a, b any) int {
+	return s.scheme.Compare(s.version(a), s.version(b))
+}
+
+// Format returns the canonical string form of a version.
+// If it is not of the type of the scheme, this function panics.
+func (s anyScheme[V]) Format(v any) string {
+	return s.scheme.Format(s.version(v))
+}
+
+func (s anyScheme[V]) Parse(str string) (any, error) {
+	v, err := s.scheme.Parse(str)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (s anyScheme[V]) Validate(str string) error {
+	return s.scheme.Validate(str)
+}
+
+// version asserts that a version is of the type of the scheme, so that a version from another scheme is not silently compared or formatted as the zero value.
+func (s anyScheme[V]) version(v any) V {
+	vv, ok := v.(V)
+	if !ok {
+		panic(fmt.Sprintf("version: scheme expects a version of type %T, not %T", vv, v))
+	}
+	return vv
+}
+
+// funcScheme is a Scheme made up of a parse function, a format function, and the Compare method of the version type.
+type funcScheme[V Comparable[V]] struct {
+	parse  func(string) (V, error)
+	format func(V) string
+}
+
+func (s funcScheme[V]) Compare(a, b V) int {
+	return a.Compare(b)
+}
+
+func (s funcScheme[V]) Format(v V) string {
+	return s.format(v)
+}
+
+func (s funcScheme[V]) Parse(str string) (V, error) {
+	return s.parse(str)
+}
+
+func (s funcScheme[V]) Validate(str string) error {
+	_, err := s.parse(str)
+	return err
+}
+
+// schemeMatcher adapts a constraint on versions of a scheme to accept plain versions.
+type schemeMatcher[V any] struct {
+	scheme  Scheme[V]
+	matcher MatcherOf[schemeVersion[V]]
+}
+
+// Match tests a version against the constraint.
+// As for other constraints, a nil version never matches.
+func (m schemeMatcher[V]) Match(v V) bool {
+	if isNil(v) {
+		return false
+	}
+	return m.matcher.Match(schemeVersion[V]{scheme: m.scheme, version: v})
+}
+
+func (m schemeMatcher[V]) String() string {
+	return fmt.Sprint(m.matcher)
+}
+
+// schemeVersion is a version paired with its scheme, so that it can be compared within a ConstraintOf.
+type schemeVersion[V any] struct {
+	scheme  Scheme[V]
+	version V
+}
+
+func (a schemeVersion[V]) Compare(b schemeVersion[V]) int {
+	return a.scheme.Compare(a.version, b.version)
+}
+
+func (v schemeVersion[V]) String() string {
+	return v.scheme.Format(v.version)
+}
@@ -0,0 +1,176 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestLookupScheme(t *testing.T) {
+	type TestCase struct {
+		Scheme   string
+		Input    []string
+		Expected []string
+	}
+
+	testCases := []TestCase{
+		{Scheme: "semver", Input: []string{"1.10.0", "1.2.0", "1.2.0-rc.1"}, Expected: []string{"1.2.0-rc.1", "1.2.0", "1.10.0"}},
+		{Scheme: "lenient", Input: []string{"1.10", "v1.2.0", "1.2.0-rc.1"}, Expected: []string{"1.2.0-rc.1", "1.2.0", "1.10.0"}},
+		{Scheme: "go", Input: []string{"v1.2", "v1.2.0-20231010123456-abcdef123456", "v1.1.9"}, Expected: []string{"v1.1.9", "v1.2.0-20231010123456-abcdef123456", "v1.2.0"}},
+		{Scheme: "pep440", Input: []string{"1.0", "1.0.dev1", "1.0rc1", "1.0.post1"}, Expected: []string{"1.0.dev1", "1.0rc1", "1.0", "1.0.post1"}},
+		{Scheme: "debian", Input: []string{"1:0.1", "2.0~rc1-3", "2.0-1"}, Expected: []string{"2.0~rc1-3", "2.0-1", "1:0.1"}},
+		{Scheme: "rpm", Input: []string{"1.0^git1", "1.0", "1.0~rc1"}, Expected: []string{"1.0~rc1", "1.0", "1.0^git1"}},
+		{Scheme: "maven", Input: []string{"1.0", "1.0-SNAPSHOT", "1.0-sp1"}, Expected: []string{"1-snapshot", "1", "1-sp-1"}},
+	}
+
+	for i, testCase := range testCases {
+		s, ok := LookupScheme(testCase.Scheme)
+		if !ok {
+			t.Errorf("test %d failed (scheme %s not found)", i, testCase.Scheme)
+			continue
+		}
+
+		versions := []any{}
+		for _, str := range testCase.Input {
+			v, err := s.Parse(str)
+			if err != nil {
+				t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+			}
+			versions = append(versions, v)
+		}
+		SortOf(s, versions)
+
+		actual := []string{}
+		for _, v := range versions {
+			actual = append(actual, s.Format(v))
+		}
+		if fmt.Sprint(actual) != fmt.Sprint(testCase.Expected) {
+			t.Errorf("test %d failed (expected %v, actual %v)", i, testCase.Expected, actual)
+		} else {
+			t.Logf("test %d passed with %v", i, actual)
+		}
+	}
+
+	if _, ok := LookupScheme("unknown"); ok {
+		t.Error("expected unknown scheme not to be found")
+	}
+	if err := LenientScheme.Validate("1.2.x"); !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("expected error %s, actual %v", ErrInvalidVersion, err)
+	}
+	if err := SemVerScheme.Validate("v1.2.0"); !errors.Is(err, ErrPrefix) {
+		t.Errorf("expected error %s, actual %v", ErrPrefix, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected comparison with another type of version to panic")
+		}
+	}()
+	s, _ := LookupScheme("semver")
+	s.Compare(MustParse("1.0.0"), MustParsePEP440("1.0"))
+}
+
+func TestRegisterScheme(t *testing.T) {
+	var f Scheme[*CalVer] = MustParseCalVerFormat("YYYY.0M.MICRO")
+	RegisterScheme("calver-test", f)
+	t.Cleanup(func() { unregisterScheme("calver-test") })
+
+	if s, ok := LookupSchemeOf[*CalVer]("calver-test"); !ok || s != f {
+		t.Errorf("expected scheme to be found with its own type")
+	}
+	if _, ok := LookupSchemeOf[*Version]("calver-test"); ok {
+		t.Errorf("expected scheme not to be found with another type")
+	}
+
+	found := false
+	for _, name := range SchemeNames() {
+		found = found || name == "calver-test"
+	}
+	if !found {
+		t.Errorf("expected calver-test in %v", SchemeNames())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected duplicate registration to panic")
+		}
+	}()
+	RegisterScheme("semver", f)
+}
+
+func TestParseRangeOf(t *testing.T) {
+	type TestCase struct {
+		Scheme   string
+		Input    string
+		Expected string
+		Match    []string
+		NoMatch  []string
+		Err      error
+	}
+
+	testCases := []TestCase{
+		{Scheme: "semver", Input: ">=1.2.0 <2.0.0", Expected: ">=1.2.0 <2.0.0", Match: []string{"1.2.0", "1.9.9"}, NoMatch: []string{"2.0.0", "1.2.0-rc.1"}},
+		{Scheme: "pep440", Input: ">=1.0rc1 <1.0.post1 || =2.0", Expected: ">=1.0rc1 <1.0.post1 || =2.0", Match: []string{"1.0rc1", "1.0", "2.0.0"}, NoMatch: []string{"1.0.post1", "1.5"}},
+		{Scheme: "debian", Input: ">1:0 <=1:2.30-1ubuntu2", Expected: ">1:0 <=1:2.30-1ubuntu2", Match: []string{"1:2.30-1", "1:0.1"}, NoMatch: []string{"2.30-1ubuntu2", "1:2.30-1ubuntu3"}},
+		{Scheme: "rpm", Input: "1.0", Expected: "=1.0", Match: []string{"0:1.0"}, NoMatch: []string{"1.0^git1"}},
+		{Scheme: "maven", Input: ">=1.0-alpha-1 <1.0", Expected: ">=1-alpha-1 <1", Match: []string{"1.0-SNAPSHOT"}, NoMatch: []string{"1.0.0.Final"}},
+		{Scheme: "pep440", Input: ">= 1.0, < 2.0, != 1.5", Expected: ">=1.0 <2.0 !=1.5", Match: []string{"1.0", "1.5.1"}, NoMatch: []string{"1.5.0", "2.0"}},
+		{Scheme: "debian", Input: "1.0~rc1 - 1.0", Expected: ">=1.0~rc1 <=1.0", Match: []string{"1.0~rc2"}, NoMatch: []string{"1.0-1"}},
+		{Scheme: "rpm", Input: "", Expected: "*", Match: []string{"1.0"}},
+		{Scheme: "rpm", Input: "* || !=*", Expected: "* || !=*", Match: []string{"1.0"}},
+		{Scheme: "semver", Input: ">=", Err: ErrInvalidConstraint},
+		{Scheme: "semver", Input: "^1.0.0", Err: ErrInvalidConstraint},
+		{Scheme: "semver", Input: ">2.0.0 <1.0.0", Err: ErrContradictoryBounds},
+		{Scheme: "pep440", Input: ">=foo", Err: ErrInvalidConstraint},
+		{Scheme: "pep440", Input: ">=*", Err: ErrInvalidConstraint},
+	}
+
+	for i, testCase := range testCases {
+		s, _ := LookupScheme(testCase.Scheme)
+		actual, err := ParseRangeOf(s, testCase.Input)
+
+		if testCase.Err != nil {
+			if !errors.Is(err, testCase.Err) {
+				t.Errorf("test %d failed (expected error %s, actual %v)", i, testCase.Err, err)
+			} else {
+				t.Logf("test %d passed with error %s for %q\n", i, err, testCase.Input)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+			continue
+		} else if str := fmt.Sprint(actual); str != testCase.Expected {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, testCase.Expected, str)
+			continue
+		}
+
+		ok := true
+		for _, str := range testCase.Match {
+			v, _ := s.Parse(str)
+			if !actual.Match(v) {
+				ok = false
+				t.Errorf("test %d failed (expected %s to match %s)", i, str, actual)
+			}
+		}
+		for _, str := range testCase.NoMatch {
+			v, _ := s.Parse(str)
+			if actual.Match(v) {
+				ok = false
+				t.Errorf("test %d failed (expected %s not to match %s)", i, str, actual)
+			}
+		}
+		if ok {
+			t.Logf("test %d passed with %s", i, actual)
+		}
+	}
+
+	r, _ := ParseRangeOf(DebianScheme, "<2.0")
+	list := ListOf[*Debian]{MustParseDebian("1.0"), MustParseDebian("2.0~rc1"), MustParseDebian("2.0")}
+	if matched := list.Match(r); len(matched) != 2 {
+		t.Errorf("list match failed (actual %v)", matched)
+	}
+
+	if r, _ := ParseRangeOf(DebianScheme, "!=1.0"); r.Match(nil) {
+		t.Errorf("expected nil version not to match %s", r)
+	}
+}
@@ -0,0 +1,286 @@
+package version
+
+import (
+	"sort"
+	"strings"
+)
+
+// Set is a normalized union of version ranges.
+//
+// The constraints in a Set are non-empty, disjoint and sorted in ascending order, and each has at most one lower and one upper bound.
+// Sets should be created with NewSet or the set operations of Constraint and Set, which maintain these properties.
+// An empty Set matches no versions.
+type Set = SetOf[*Version]
+
+// SetOf is a normalized union of ranges of versions of any comparable type, as described by Set.
+type SetOf[V Comparable[V]] []*ConstraintOf[V]
+
+// NewSet creates a Set matching any version that is matched by at least one of the given constraints.
+// A nil constraint matches any version.
+func NewSet(cs ...*Constraint) Set {
+	return NewSetOf(cs...)
+}
+
+// NewSetOf creates a SetOf matching any version that is matched by at least one of the given constraints, as described by NewSet.
+func NewSetOf[V Comparable[V]](cs ...*ConstraintOf[V]) SetOf[V] {
+	ivs := make([]*interval[V], len(cs))
+	for i, c := range cs {
+		ivs[i] = c.interval()
+	}
+	return newSet(ivs)
+}
+
+// Complement returns the set of versions that are not in this set.
+func (s SetOf[V]) Complement() SetOf[V] {
+	ivs := []*interval[V]{}
+	var lower *bound[V]
+
+	for i, iv := range newSet(s.intervals()).intervals() {
+		if i > 0 || iv.Lower != nil {
+			ivs = append(ivs, &interval[V]{Lower: lower, Upper: iv.Lower.flip()})
+		}
+		lower = iv.Upper.flip()
+		if lower == nil {
+			return newSet(ivs)
+		}
+	}
+
+	return newSet(append(ivs, &interval[V]{Lower: lower}))
+}
+
+// Contains determines whether every version in another set is also in this set.
+func (s SetOf[V]) Contains(other SetOf[V]) bool {
+	return other.Intersect(s.Complement()).IsEmpty()
+}
+
+// Intersect returns the set of versions that are in both this set and another set.
+func (s SetOf[V]) Intersect(other SetOf[V]) SetOf[V] {
+	ivs := []*interval[V]{}
+
+	for _, a := range s {
+		for _, b := range other {
+			iv := a.interval()
+			bv := b.interval()
+			if bv.Lower != nil {
+				iv.SetLower(bv.Lower.Version, bv.Lower.Inclusive)
+			}
+			if bv.Upper != nil {
+				iv.SetUpper(bv.Upper.Version, bv.Upper.Inclusive)
+			}
+			ivs = append(ivs, iv)
+		}
+	}
+
+	return newSet(ivs)
+}
+
+// IsEmpty determines whether the set contains no versions.
+func (s SetOf[V]) IsEmpty() bool {
+	return len(newSet(s.intervals())) == 0
+}
+
+// Match tests a version against each range in the set.
+func (s SetOf[V]) Match(v V) bool {
+	if isNil(v) {
+		return false
+	}
+
+	for _, c := range s {
+		if c.Match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Overlaps determines whether any version is in both this set and another set.
+func (s SetOf[V]) Overlaps(other SetOf[V]) bool {
+	return !s.Intersect(other).IsEmpty()
+}
+
+// String returns the canonical form of the set, such as >=1.2.0 <1.5.0 || >=2.0.0.
+// An empty set is written as <0.0.0-0 for *Version, or !=* for other types of version, which matches no versions.
+func (s SetOf[V]) String() string {
+	if len(s) == 0 {
+		return noneOf[V]()
+	}
+
+	strs := make([]string, len(s))
+	for i, c := range s {
+		strs[i] = c.String()
+	}
+	return strings.Join(strs, " || ")
+}
+
+// Union returns the set of versions that are in either this set or another set.
+func (s SetOf[V]) Union(other SetOf[V]) SetOf[V] {
+	return newSet(append(s.intervals(), other.intervals()...))
+}
+
+func (s SetOf[V]) intervals() []*interval[V] {
+	ivs := make([]*interval[V], len(s))
+	for i, c := range s {
+		ivs[i] = c.interval()
+	}
+	return ivs
+}
+
+// flip returns the opposite bound at the same version, such as <=1.0.0 for >1.0.0.
+// A nil bound remains nil.
+func (b *bound[V]) flip() *bound[V] {
+	if b == nil {
+		return nil
+	}
+	return &bound[V]{Version: b.Version, Inclusive: !b.Inclusive}
+}
+
+// IsEmpty determines whether the interval cannot contain any version.
+// An upper bound below the lowest version of its type, such as <0.0.0-0, contains no versions.
+func (iv *interval[V]) IsEmpty() bool {
+	if v, ok := lowestOf[V](); ok && iv.Upper != nil {
+		if cmp := iv.Upper.Version.Compare(v); cmp < 0 || cmp == 0 && !iv.Upper.Inclusive {
+			return true
+		}
+	}
+	if iv.Lower == nil || iv.Upper == nil {
+		return false
+	}
+
+	cmp := iv.Lower.Version.Compare(iv.Upper.Version)
+	return cmp > 0 || cmp == 0 && !(iv.Lower.Inclusive && iv.Upper.Inclusive)
+}
+
+// normalize returns the interval without a lower bound that every version of its type satisfies, such as >=0.0.0-0.
+func (iv *interval[V]) normalize() *interval[V] {
+	if v, ok := lowestOf[V](); ok && iv.Lower != nil {
+		if cmp := iv.Lower.Version.Compare(v); cmp < 0 || cmp == 0 && iv.Lower.Inclusive {
+			return &interval[V]{Upper: iv.Upper}
+		}
+	}
+	return iv
+}
+
+// compareLower compares two lower bounds, where nil is unbounded.
+// An inclusive bound is lower than an exclusive bound at the same version.
+func compareLower[V Comparable[V]](a, b *bound[V]) int {
+	if a == nil && b == nil {
+		return 0
+	} else if a == nil {
+		return -1
+	} else if b == nil {
+		return 1
+	}
+
+	if cmp := a.Version.Compare(b.Version); cmp != 0 {
+		return cmp
+	} else if a.Inclusive == b.Inclusive {
+		return 0
+	} else if a.Inclusive {
+		return -1
+	}
+	return 1
+}
+
+// compareUpper compares two upper bounds, where nil is unbounded.
+// An exclusive bound is lower than an inclusive bound at the same version.
+func compareUpper[V Comparable[V]](a, b *bound[V]) int {
+	if a == nil && b == nil {
+		return 0
+	} else if a == nil {
+		return 1
+	} else if b == nil {
+		return -1
+	}
+
+	if cmp := a.Version.Compare(b.Version); cmp != 0 {
+		return cmp
+	} else if a.Inclusive == b.Inclusive {
+		return 0
+	} else if a.Inclusive {
+		return 1
+	}
+	return -1
+}
+
+// newSet normalizes intervals into a Set by removing empty intervals and unneeded lower bounds, sorting the rest and merging any that overlap or are adjacent.
+func newSet[V Comparable[V]](ivs []*interval[V]) SetOf[V] {
+	nonEmpty := []*interval[V]{}
+	for _, iv := range ivs {
+		if !iv.IsEmpty() {
+			nonEmpty = append(nonEmpty, iv.normalize())
+		}
+	}
+
+	sort.SliceStable(nonEmpty, func(i, j int) bool {
+		return compareLower(nonEmpty[i].Lower, nonEmpty[j].Lower) < 0
+	})
+
+	merged := []*interval[V]{}
+	for _, iv := range nonEmpty {
+		if n := len(merged); n > 0 && touches(merged[n-1], iv) {
+			if compareUpper(iv.Upper, merged[n-1].Upper) > 0 {
+				merged[n-1].Upper = iv.Upper
+			}
+			continue
+		}
+		merged = append(merged, &interval[V]{Lower: iv.Lower, Upper: iv.Upper})
+	}
+
+	s := make(SetOf[V], len(merged))
+	for i, iv := range merged {
+		s[i] = iv.Constraint()
+	}
+	return s
+}
+
+// toSet converts a matcher to an equivalent Set, if it is made up only of constraints, sets, unions, intersections and exclusions.
+func toSet[V Comparable[V]](m MatcherOf[V]) (SetOf[V], bool) {
+	switch m := m.(type) {
+	case nil:
+		return NewSetOf[V](nil), true
+	case *ConstraintOf[V]:
+		return NewSetOf(m), true
+	case SetOf[V]:
+		return newSet(m.intervals()), true
+	case UnionOf[V]:
+		s := SetOf[V]{}
+		for _, um := range m {
+			us, ok := toSet(um)
+			if !ok {
+				return nil, false
+			}
+			s = s.Union(us)
+		}
+		return s, true
+	case IntersectionOf[V]:
+		s := NewSetOf[V](nil)
+		for _, im := range m {
+			is, ok := toSet(im)
+			if !ok {
+				return nil, false
+			}
+			s = s.Intersect(is)
+		}
+		return s, true
+	case ExclusionOf[V]:
+		if m.Matcher == nil {
+			return NewSetOf[V](nil), true
+		}
+		es, ok := toSet(m.Matcher)
+		if !ok {
+			return nil, false
+		}
+		return es.Complement(), true
+	}
+	return nil, false
+}
+
+// touches determines whether interval b, which starts no lower than interval a, overlaps or is adjacent to a, so that they can be merged.
+func touches[V Comparable[V]](a, b *interval[V]) bool {
+	if a.Upper == nil || b.Lower == nil {
+		return true
+	}
+
+	cmp := b.Lower.Version.Compare(a.Upper.Version)
+	return cmp < 0 || cmp == 0 && (a.Upper.Inclusive || b.Lower.Inclusive)
+}
@@ -0,0 +1,183 @@
+package version
+
+import "testing"
+
+func TestSet_Operations(t *testing.T) {
+	type TestCase struct {
+		Actual   Set
+		Expected Set
+	}
+
+	testCases := []TestCase{
+		// Normalization
+		{Actual: NewSet(), Expected: Set{}},
+		{Actual: NewSet(nil), Expected: Set{{}}},
+		{Actual: NewSet(&Constraint{Gt: MustParse("2.0.0"), Lt: MustParse("1.0.0")}), Expected: Set{}},
+		{Actual: NewSet(&Constraint{Gt: MustParse("1.0.0"), Lte: MustParse("1.0.0")}), Expected: Set{}},
+		{Actual: NewSet(&Constraint{Gte: MustParse("1.0.0"), Lte: MustParse("1.0.0")}), Expected: Set{{Gte: MustParse("1.0.0"), Lte: MustParse("1.0.0")}}},
+		{Actual: NewSet(&Constraint{Gt: MustParse("1.0.0"), Gte: MustParse("0.5.0")}), Expected: Set{{Gt: MustParse("1.0.0")}}},
+		{Actual: NewSet(&Constraint{Lt: MustParse("0.0.0-0")}), Expected: Set{}},
+		{Actual: NewSet(&Constraint{Gte: MustParse("0.0.0-0"), Lt: MustParse("1.0.0")}), Expected: Set{{Lt: MustParse("1.0.0")}}},
+		{
+			Actual:   NewSet(&Constraint{Gte: MustParse("2.0.0")}, &Constraint{Gte: MustParse("1.0.0"), Lt: MustParse("1.5.0")}),
+			Expected: Set{{Gte: MustParse("1.0.0"), Lt: MustParse("1.5.0")}, {Gte: MustParse("2.0.0")}},
+		},
+		{
+			Actual:   NewSet(&Constraint{Gte: MustParse("1.0.0"), Lt: MustParse("1.5.0")}, &Constraint{Gte: MustParse("1.5.0"), Lt: MustParse("2.0.0")}),
+			Expected: Set{{Gte: MustParse("1.0.0"), Lt: MustParse("2.0.0")}},
+		},
+		{
+			Actual:   NewSet(&Constraint{Gte: MustParse("1.0.0"), Lt: MustParse("1.5.0")}, &Constraint{Gt: MustParse("1.5.0"), Lt: MustParse("2.0.0")}),
+			Expected: Set{{Gte: MustParse("1.0.0"), Lt: MustParse("1.5.0")}, {Gt: MustParse("1.5.0"), Lt: MustParse("2.0.0")}},
+		},
+
+		// Intersect
+		{
+			Actual:   (&Constraint{Gte: MustParse("1.0.0"), Lt: MustParse("2.0.0")}).Intersect(&Constraint{Gt: MustParse("1.5.0")}),
+			Expected: Set{{Gt: MustParse("1.5.0"), Lt: MustParse("2.0.0")}},
+		},
+		{
+			Actual:   (&Constraint{Gte: MustParse("1.0.0"), Lt: MustParse("2.0.0")}).Intersect(&Constraint{Gte: MustParse("2.0.0")}),
+			Expected: Set{},
+		},
+		{
+			Actual:   (&Constraint{Gte: MustParse("1.0.0"), Lte: MustParse("2.0.0")}).Intersect(&Constraint{Gte: MustParse("2.0.0")}),
+			Expected: Set{{Gte: MustParse("2.0.0"), Lte: MustParse("2.0.0")}},
+		},
+		{
+			Actual:   NewSet(&Constraint{Lt: MustParse("1.0.0")}, &Constraint{Gt: MustParse("2.0.0")}).Intersect(NewSet(&Constraint{Gte: MustParse("0.5.0"), Lte: MustParse("3.0.0")})),
+			Expected: Set{{Gte: MustParse("0.5.0"), Lt: MustParse("1.0.0")}, {Gt: MustParse("2.0.0"), Lte: MustParse("3.0.0")}},
+		},
+
+		// Union
+		{
+			Actual:   (&Constraint{Gte: MustParse("1.0.0"), Lt: MustParse("2.0.0")}).Union(&Constraint{Gt: MustParse("1.5.0"), Lte: MustParse("3.0.0")}),
+			Expected: Set{{Gte: MustParse("1.0.0"), Lte: MustParse("3.0.0")}},
+		},
+		{
+			Actual:   (&Constraint{Gte: MustParse("1.0.0"), Lt: MustParse("2.0.0")}).Union(&Constraint{Gte: MustParse("3.0.0")}),
+			Expected: Set{{Gte: MustParse("1.0.0"), Lt: MustParse("2.0.0")}, {Gte: MustParse("3.0.0")}},
+		},
+		{
+			Actual:   (&Constraint{Lt: MustParse("2.0.0")}).Union(&Constraint{Gte: MustParse("2.0.0")}),
+			Expected: Set{{}},
+		},
+
+		// Complement
+		{Actual: (&Constraint{}).Complement(), Expected: Set{}},
+		{Actual: Set{}.Complement(), Expected: Set{{}}},
+		{Actual: (&Constraint{Gte: MustParse("1.0.0")}).Complement(), Expected: Set{{Lt: MustParse("1.0.0")}}},
+		{Actual: (&Constraint{Lte: MustParse("1.0.0")}).Complement(), Expected: Set{{Gt: MustParse("1.0.0")}}},
+		{
+			Actual:   (&Constraint{Gt: MustParse("1.0.0"), Lte: MustParse("2.0.0")}).Complement(),
+			Expected: Set{{Lte: MustParse("1.0.0")}, {Gt: MustParse("2.0.0")}},
+		},
+		{
+			Actual:   NewSet(&Constraint{Lt: MustParse("1.0.0")}, &Constraint{Gte: MustParse("1.5.0"), Lt: MustParse("2.0.0")}).Complement(),
+			Expected: Set{{Gte: MustParse("1.0.0"), Lt: MustParse("1.5.0")}, {Gte: MustParse("2.0.0")}},
+		},
+	}
+
+	for i, testCase := range testCases {
+		if !setsEqual(testCase.Actual, testCase.Expected) {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, testCase.Expected, testCase.Actual)
+		} else {
+			t.Logf("test %d passed with %s", i, testCase.Actual)
+		}
+	}
+}
+
+func TestSet_Predicates(t *testing.T) {
+	type TestCase struct {
+		A        *Constraint
+		B        *Constraint
+		Contains bool
+		Overlaps bool
+	}
+
+	testCases := []TestCase{
+		{A: &Constraint{}, B: &Constraint{Gte: MustParse("1.0.0")}, Contains: true, Overlaps: true},
+		{A: &Constraint{Gte: MustParse("1.0.0")}, B: &Constraint{}, Contains: false, Overlaps: true},
+		{A: &Constraint{Gte: MustParse("1.0.0"), Lt: MustParse("2.0.0")}, B: &Constraint{Gte: MustParse("1.2.0"), Lt: MustParse("1.5.0")}, Contains: true, Overlaps: true},
+		{A: &Constraint{Gte: MustParse("1.0.0"), Lt: MustParse("2.0.0")}, B: &Constraint{Gte: MustParse("1.2.0"), Lte: MustParse("2.0.0")}, Contains: false, Overlaps: true},
+		{A: &Constraint{Gte: MustParse("1.0.0"), Lt: MustParse("2.0.0")}, B: &Constraint{Gte: MustParse("2.0.0")}, Contains: false, Overlaps: false},
+		{A: &Constraint{Gte: MustParse("1.0.0"), Lte: MustParse("2.0.0")}, B: &Constraint{Gte: MustParse("2.0.0")}, Contains: false, Overlaps: true},
+		{A: &Constraint{Gt: MustParse("1.0.0")}, B: &Constraint{Gte: MustParse("1.0.0")}, Contains: false, Overlaps: true},
+		{A: &Constraint{Gte: MustParse("1.0.0")}, B: &Constraint{Gt: MustParse("1.0.0")}, Contains: true, Overlaps: true},
+		{A: &Constraint{Gte: MustParse("1.0.0")}, B: &Constraint{Gt: MustParse("2.0.0"), Lt: MustParse("1.0.0")}, Contains: true, Overlaps: false},
+		{A: &Constraint{Gte: MustParse("0.0.0-0")}, B: &Constraint{}, Contains: true, Overlaps: true},
+		{A: &Constraint{}, B: &Constraint{Lt: MustParse("0.0.0-0")}, Contains: true, Overlaps: false},
+	}
+
+	for i, testCase := range testCases {
+		contains := testCase.A.Contains(testCase.B)
+		overlaps := testCase.A.Overlaps(testCase.B)
+		if contains != testCase.Contains || overlaps != testCase.Overlaps {
+			t.Errorf("test %d failed (expected contains %v overlaps %v, actual contains %v overlaps %v)", i, testCase.Contains, testCase.Overlaps, contains, overlaps)
+		} else {
+			t.Logf("test %d passed", i)
+		}
+	}
+}
+
+func TestConstraint_IsEmpty(t *testing.T) {
+	type TestCase struct {
+		C        *Constraint
+		Expected bool
+	}
+
+	testCases := []TestCase{
+		{Expected: false},
+		{C: &Constraint{}, Expected: false},
+		{C: &Constraint{Gt: MustParse("2.0.0"), Lt: MustParse("1.0.0")}, Expected: true},
+		{C: &Constraint{Gte: MustParse("1.0.0"), Lt: MustParse("1.0.0")}, Expected: true},
+		{C: &Constraint{Gt: MustParse("1.0.0"), Lte: MustParse("1.0.0")}, Expected: true},
+		{C: &Constraint{Gte: MustParse("1.0.0"), Lte: MustParse("1.0.0")}, Expected: false},
+		{C: &Constraint{Gte: MustParse("1.0.0"), Lt: MustParse("1.0.1-0")}, Expected: false},
+		{C: &Constraint{Gt: MustParse("1.0.0"), Gte: MustParse("3.0.0"), Lt: MustParse("2.0.0")}, Expected: false},
+		{C: &Constraint{Lt: MustParse("0.0.0-0")}, Expected: true},
+		{C: &Constraint{Lte: MustParse("0.0.0-0")}, Expected: false},
+	}
+
+	for i, testCase := range testCases {
+		actual := testCase.C.IsEmpty()
+		if actual != testCase.Expected {
+			t.Errorf("test %d failed (expected %v, actual %v)", i, testCase.Expected, actual)
+		} else {
+			t.Logf("test %d passed with %v", i, actual)
+		}
+	}
+}
+
+func TestSetOf(t *testing.T) {
+	a := &ConstraintOf[*Debian]{Gte: MustParseDebian("1.0~rc1"), Lt: MustParseDebian("2.0")}
+	b := &ConstraintOf[*Debian]{Gte: MustParseDebian("1:0")}
+
+	if s := a.Union(b); s.String() != ">=1.0~rc1 <2.0 || >=1:0" {
+		t.Errorf("union failed (actual %s)", s)
+	}
+	if s := a.Union(b).Complement(); s.String() != "<1.0~rc1 || >=2.0 <1:0" || s.Match(MustParseDebian("1.5")) || !s.Match(MustParseDebian("2.0-1")) {
+		t.Errorf("complement failed (actual %s)", s)
+	}
+	if s := a.Intersect(b); !s.IsEmpty() || s.String() != "!=*" {
+		t.Errorf("intersect failed (actual %s)", s)
+	}
+	if !b.Contains(&ConstraintOf[*Debian]{Gt: MustParseDebian("1:2.0")}) || a.Overlaps(b) {
+		t.Errorf("predicates failed for %s and %s", a, b)
+	}
+}
+
+func setsEqual(a, b Set) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if (a[i].Gt == nil) != (b[i].Gt == nil) || (a[i].Gte == nil) != (b[i].Gte == nil) || (a[i].Lt == nil) != (b[i].Lt == nil) || (a[i].Lte == nil) != (b[i].Lte == nil) {
+			return false
+		}
+		if !a[i].Gt.Equal(b[i].Gt) || !a[i].Gte.Equal(b[i].Gte) || !a[i].Lt.Equal(b[i].Lt) || !a[i].Lte.Equal(b[i].Lte) {
+			return false
+		}
+	}
+	return true
+}
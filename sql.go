@@ -0,0 +1,322 @@
+package version
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// Sortable wraps a version for storage in a database, using an encoding whose byte order matches the order of versions described by Compare.
+// When stored in a text column with binary collation (such as COLLATE "C" in PostgreSQL, or the default BINARY collation in SQLite), ORDER BY sorts versions in the same order as List.
+//
+// Each numeric component is written as a letter giving its number of digits (A for 1 digit, B for 2, and so on), followed by its digits.
+// Trailing zero Segments are omitted, as they do not affect the order of versions.
+// These are followed by a period for a normal version, or a hyphen and the pre-release identifiers for a pre-release, and finally any build metadata.
+// For example, 1.20.3 is written as A1B20A3. and 1.0.0-rc.1 is written as A1A0A0-~rc,A1,
+//
+// The original Text of the version is not preserved, so a decoded version is formatted by SemanticString.
+// Numeric components of more than 52 digits cannot be encoded.
+type Sortable struct {
+	Version *Version
+}
+
+const (
+	sortableAlphanumeric = '~'
+	sortableBuild        = '+'
+	sortablePreRelease   = '-'
+	sortableRelease      = '.'
+	sortableTerminator   = ','
+)
+
+// Scan decodes a version from a database value using Parse.
+// https://pkg.go.dev/database/sql#Scanner
+//
+// A NULL value cannot be scanned into a Version, and returns an ErrScanNull error.
+// To scan NULL as a nil version instead, scan into a **Version.
+func (v *Version) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		return newError(ErrScanNull, fmt.Sprintf("%T", v))
+	case string:
+		return v.UnmarshalText([]byte(src))
+	case []byte:
+		return v.UnmarshalText(src)
+	}
+	return newError(ErrScanType, fmt.Sprintf("%T", src))
+}
+
+// Value encodes the version as its string form for storage in a database.
+// https://pkg.go.dev/database/sql/driver#Valuer
+//
+// A nil *Version is stored as NULL.
+func (v Version) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+// Scan decodes a constraint from a database value using ParseConstraint.
+// https://pkg.go.dev/database/sql#Scanner
+//
+// A NULL value resets the constraint to its zero value, which matches all versions.
+func (c *ConstraintOf[V]) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*c = ConstraintOf[V]{}
+		return nil
+	case string:
+		return c.UnmarshalText([]byte(src))
+	case []byte:
+		return c.UnmarshalText(src)
+	}
+	return newError(ErrScanType, fmt.Sprintf("%T", src))
+}
+
+// Value encodes the constraint in canonical form for storage in a database.
+// https://pkg.go.dev/database/sql/driver#Valuer
+//
+// A nil *Constraint is stored as NULL.
+func (c ConstraintOf[V]) Value() (driver.Value, error) {
+	text, err := c.MarshalText()
+	return string(text), err
+}
+
+// Scan decodes a set from a database value using ParseConstraint.
+// https://pkg.go.dev/database/sql#Scanner
+//
+// A NULL value resets the set to nil, which matches no versions.
+func (s *SetOf[V]) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case string:
+		return s.UnmarshalText([]byte(src))
+	case []byte:
+		return s.UnmarshalText(src)
+	}
+	return newError(ErrScanType, fmt.Sprintf("%T", src))
+}
+
+// Value encodes the set in canonical form for storage in a database.
+// https://pkg.go.dev/database/sql/driver#Valuer
+func (s SetOf[V]) Value() (driver.Value, error) {
+	return s.String(), nil
+}
+
+// Scan decodes a Go module version from a database value using ParseModule.
+// https://pkg.go.dev/database/sql#Scanner
+//
+// As for Version, a NULL value returns an ErrScanNull error.
+func (m *ModuleVersion) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		return newError(ErrScanNull, fmt.Sprintf("%T", m))
+	case string:
+		return m.UnmarshalText([]byte(src))
+	case []byte:
+		return m.UnmarshalText(src)
+	}
+	return newError(ErrScanType, fmt.Sprintf("%T", src))
+}
+
+// Value encodes the module version in canonical form for storage in a database.
+// https://pkg.go.dev/database/sql/driver#Valuer
+func (m ModuleVersion) Value() (driver.Value, error) {
+	return m.Canonical(), nil
+}
+
+// Scan decodes a version from its sortable form.
+// https://pkg.go.dev/database/sql#Scanner
+//
+// A NULL value sets the version to nil.
+func (s *Sortable) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		s.Version = nil
+		return nil
+	case string:
+		v, err := ParseSortable(src)
+		if err != nil {
+			return err
+		}
+		s.Version = v
+		return nil
+	case []byte:
+		return s.Scan(string(src))
+	}
+	return newError(ErrScanType, fmt.Sprintf("%T", src))
+}
+
+// Value encodes the version in its sortable form for storage in a database.
+// https://pkg.go.dev/database/sql/driver#Valuer
+//
+// A nil version is stored as NULL.
+func (s Sortable) Value() (driver.Value, error) {
+	if s.Version == nil {
+		return nil, nil
+	}
+	return s.Version.SortableString()
+}
+
+// ParseSortable decodes a version from the sortable form described by Sortable.
+func ParseSortable(str string) (*Version, error) {
+	rest := str
+	numbers := []Number{}
+
+	for len(numbers) < 3 || len(rest) > 0 && rest[0] >= 'A' {
+		digits, r, ok := readSortableNumber(rest)
+		if !ok {
+			return nil, newError(ErrInvalidSortable, str)
+		}
+		numbers = append(numbers, Number(digits))
+		rest = r
+	}
+
+	v := newVersion(numbers)
+	if len(rest) == 0 {
+		return nil, newError(ErrInvalidSortable, str)
+	}
+
+	switch rest[0] {
+	case sortableRelease:
+		rest = rest[1:]
+	case sortablePreRelease:
+		rest = rest[1:]
+		for len(rest) > 0 && rest[0] != sortableBuild {
+			var id string
+			if rest[0] == sortableAlphanumeric {
+				end := strings.IndexByte(rest, sortableTerminator)
+				if end < 2 {
+					return nil, newError(ErrInvalidSortable, str)
+				}
+				id = rest[1:end]
+				rest = rest[end:]
+			} else {
+				digits, r, ok := readSortableNumber(rest)
+				if !ok {
+					return nil, newError(ErrInvalidSortable, str)
+				}
+				id = digits
+				rest = r
+			}
+			if len(rest) == 0 || rest[0] != sortableTerminator {
+				return nil, newError(ErrInvalidSortable, str)
+			}
+			rest = rest[1:]
+			v.PreRelease = append(v.PreRelease, Identifier(id))
+		}
+		if len(v.PreRelease) == 0 {
+			return nil, newError(ErrInvalidSortable, str)
+		}
+	default:
+		return nil, newError(ErrInvalidSortable, str)
+	}
+
+	if len(rest) > 0 {
+		if rest[0] != sortableBuild || len(rest) == 1 {
+			return nil, newError(ErrInvalidSortable, str)
+		}
+		v.Build = splitIdentifiers(rest[1:])
+	}
+
+	v.Extension = formatExtension(v.PreRelease, v.Build)
+	return v, nil
+}
+
+// SortableString returns the version in the sortable form described by Sortable.
+// An error is returned if the version cannot be encoded.
+func (v *Version) SortableString() (string, error) {
+	if v == nil {
+		return "", nil
+	}
+
+	str := ""
+	for _, n := range v.sortNumbers() {
+		enc, ok := sortableNumber(string(n))
+		if !ok {
+			return "", notSortable(v, string(n))
+		}
+		str += enc
+	}
+
+	preRelease, build := v.identifiers()
+
+	if len(preRelease) == 0 {
+		str += string(sortableRelease)
+	} else {
+		str += string(sortablePreRelease)
+		for _, id := range preRelease {
+			if id.Numeric() {
+				enc, ok := sortableNumber(string(id))
+				if !ok {
+					return "", notSortable(v, string(id))
+				}
+				str += enc
+			} else {
+				for i := 0; i < len(id); i++ {
+					if id[i] <= sortableTerminator {
+						return "", newError(ErrNotSortable, v.String())
+					}
+				}
+				str += string(sortableAlphanumeric) + string(id)
+			}
+			str += string(sortableTerminator)
+		}
+	}
+
+	if len(build) > 0 {
+		str += string(sortableBuild) + joinIdentifiers(build)
+	}
+
+	return str, nil
+}
+
+// readSortableNumber reads a number in sortable form from the start of a string, returning its digits and the remainder of the string.
+func readSortableNumber(str string) (string, string, bool) {
+	if len(str) == 0 {
+		return "", "", false
+	}
+
+	n := 0
+	if c := str[0]; c >= 'A' && c <= 'Z' {
+		n = int(c-'A') + 1
+	} else if c >= 'a' && c <= 'z' {
+		n = int(c-'a') + 27
+	} else {
+		return "", "", false
+	}
+
+	if len(str) < n+1 || !isNumeric(str[1:n+1]) || n > 1 && str[1] == '0' {
+		return "", "", false
+	}
+	return str[1 : n+1], str[n+1:], true
+}
+
+// sortableNumber encodes a string of decimal digits in sortable form, prefixed by a letter giving its length.
+func sortableNumber(digits string) (string, bool) {
+	if !isNumeric(digits) {
+		return "", false
+	}
+
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		digits = "0"
+	}
+
+	n := len(digits)
+	if n <= 26 {
+		return string(rune('A'+n-1)) + digits, true
+	} else if n <= 52 {
+		return string(rune('a'+n-27)) + digits, true
+	}
+	return "", false
+}
+
+// notSortable returns ErrNotSortable for a version with a number that cannot be encoded.
+// If the number is valid but too long, the error is of KindOverflow.
+func notSortable(v *Version, digits string) Error {
+	err := newError(ErrNotSortable, v.String())
+	if isNumeric(digits) {
+		err.Kind = KindOverflow
+	}
+	return err
+}
@@ -0,0 +1,251 @@
+package version
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"testing"
+)
+
+func TestVersion_Scan(t *testing.T) {
+	type TestCase struct {
+		Input    any
+		Expected *Version
+		Err      error
+	}
+
+	testCases := []TestCase{
+		{Input: "v1.2.3", Expected: MustParse("v1.2.3")},
+		{Input: []byte("1.2.3-rc.1"), Expected: MustParse("1.2.3-rc.1")},
+		{Input: nil, Err: ErrScanNull},
+		{Input: "invalid version", Err: ErrInvalidVersion},
+		{Input: 123, Err: ErrScanType},
+	}
+
+	for i, testCase := range testCases {
+		actual := &Version{Major: 9}
+		err := actual.Scan(testCase.Input)
+
+		if testCase.Err != nil {
+			if err == nil {
+				t.Errorf("test %d failed (expected error %s, actual nil)", i, testCase.Err)
+			} else if !errors.Is(err, testCase.Err) {
+				t.Errorf("test %d failed (expected error %s, actual error %s)", i, testCase.Err, err)
+			} else {
+				t.Logf("test %d passed with error %s", i, err)
+			}
+		} else if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+		} else if actual.String() != testCase.Expected.String() {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, testCase.Expected, actual)
+		} else {
+			t.Logf("test %d passed with %s", i, actual)
+		}
+	}
+}
+
+func TestVersion_ScanNull(t *testing.T) {
+	type TestCase struct {
+		Input    driver.Value
+		Expected string
+	}
+
+	testCases := []TestCase{
+		{Input: nil},
+		{Input: "v1.2.3", Expected: "v1.2.3"},
+	}
+
+	for i, testCase := range testCases {
+		db := sql.OpenDB(&valueDB{value: testCase.Input})
+
+		actual := MustParse("9.9.9")
+		if err := db.QueryRow("SELECT version").Scan(&actual); err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+		} else if actual.String() != testCase.Expected || (actual == nil) != (testCase.Input == nil) {
+			t.Errorf("test %d failed (expected %q, actual %v)", i, testCase.Expected, actual)
+		} else {
+			t.Logf("test %d passed with %v", i, actual)
+		}
+
+		direct := Version{}
+		err := db.QueryRow("SELECT version").Scan(&direct)
+		if testCase.Input == nil && !errors.Is(err, ErrScanNull) {
+			t.Errorf("test %d failed (expected error %s, actual %v)", i, ErrScanNull, err)
+		}
+		db.Close()
+	}
+}
+
+func TestVersion_Value(t *testing.T) {
+	v, err := MustParse("v1.2.3").Value()
+	if err != nil || v != "v1.2.3" {
+		t.Errorf("version value failed (expected v1.2.3, actual %v with error %v)", v, err)
+	}
+
+	c, err := (&Constraint{Gte: MustParse("1.2.0"), Lt: MustParse("2.0.0")}).Value()
+	if err != nil || c != ">=1.2.0 <2.0.0" {
+		t.Errorf("constraint value failed (expected >=1.2.0 <2.0.0, actual %v with error %v)", c, err)
+	}
+
+	s, err := (Sortable{}).Value()
+	if err != nil || s != nil {
+		t.Errorf("sortable value failed (expected nil, actual %v with error %v)", s, err)
+	}
+
+	sortable := Sortable{}
+	if err := json.Unmarshal([]byte(`{"Version":"1.2.3"}`), &sortable); err != nil || sortable.Version.String() != "1.2.3" {
+		t.Errorf("sortable unmarshal failed (actual %v with error %v)", sortable.Version, err)
+	}
+
+	constraint := &Constraint{}
+	if err := constraint.Scan("^1.2"); err != nil || constraint.String() != ">=1.2.0 <2.0.0-0" {
+		t.Errorf("constraint scan failed (actual %s with error %v)", constraint, err)
+	}
+
+	set := Set{}
+	if err := set.Scan([]byte("^1.2 || ^2.3")); err != nil || set.String() != ">=1.2.0 <2.0.0-0 || >=2.3.0 <3.0.0-0" {
+		t.Errorf("set scan failed (actual %s with error %v)", set, err)
+	}
+}
+
+func TestVersion_SortableString(t *testing.T) {
+	type TestCase struct {
+		Input    *Version
+		Expected string
+		Decoded  string
+	}
+
+	testCases := []TestCase{
+		{Input: MustParse("1.2.3"), Expected: "A1A2A3.", Decoded: "1.2.3"},
+		{Input: MustParse("v1.20.3"), Expected: "A1B20A3.", Decoded: "1.20.3"},
+		{Input: MustParse("0.0.0"), Expected: "A0A0A0.", Decoded: "0.0.0"},
+		{Input: MustParse("1.0.0-rc.1"), Expected: "A1A0A0-~rc,A1,", Decoded: "1.0.0-rc.1"},
+		{Input: MustParse("1.0.0-rc.01+build.5"), Expected: "A1A0A0-~rc,A1,+build.5", Decoded: "1.0.0-rc.1+build.5"},
+		{Input: MustParse("1.0.0+build.5"), Expected: "A1A0A0.+build.5", Decoded: "1.0.0+build.5"},
+		{Input: MustParse("1.2.0a"), Expected: "A1A2A0-~a,", Decoded: "1.2.0-a"},
+		{Input: MustParse("1.2.3.4-rc.1"), Expected: "A1A2A3A4-~rc,A1,", Decoded: "1.2.3.4-rc.1"},
+		{Input: MustParse("1.2.3.0.10.0"), Expected: "A1A2A3A0B10.", Decoded: "1.2.3.0.10"},
+	}
+
+	for i, testCase := range testCases {
+		actual, err := testCase.Input.SortableString()
+		if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+			continue
+		}
+		if actual != testCase.Expected {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, testCase.Expected, actual)
+			continue
+		}
+
+		decoded, err := ParseSortable(actual)
+		if err != nil {
+			t.Errorf("test %d failed (expected error nil, actual error %s)", i, err)
+		} else if decoded.SemanticString() != testCase.Decoded {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, testCase.Decoded, decoded.SemanticString())
+		} else {
+			t.Logf("test %d passed with %s", i, actual)
+		}
+	}
+}
+
+func TestSortable_Order(t *testing.T) {
+	list := List{}
+	for _, str := range []string{
+		"0.0.0", "0.0.1", "0.1.0", "1.0.0", "1.2.3", "1.2.10", "1.10.0", "1.20.0", "1.3.0", "2.0.0", "10.0.0", "9.9.9",
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1",
+		"1.0.0-0", "1.0.0-1", "1.0.0-10", "1.0.0-9", "1.0.0-a-b", "1.0.0-a", "1.0.0-A", "1.0.0-a.0", "1.0.0-a.a",
+		"1.0.0-rc.1+build.1", "1.2.3+build", "123456789.0.0", "1.0.0-99999999999999999999999",
+		"123456789012345678901234567890.0.0", "99999999999999999999.0.0", "1.2.100000000000000000000",
+	} {
+		list = append(list, MustParse(str))
+	}
+
+	keys := make([]string, len(list))
+	for i, v := range list {
+		key, err := v.SortableString()
+		if err != nil {
+			t.Fatalf("encoding %s failed with error %s", v, err)
+		}
+		keys[i] = key
+	}
+
+	for i := range list {
+		for j := range list {
+			cmp := list[i].Compare(list[j])
+			keyCmp := compareStrings(keys[i], keys[j])
+			if cmp != 0 && cmp != keyCmp {
+				t.Errorf("order of %s and %s failed (expected %d, actual %d for %s and %s)", list[i], list[j], cmp, keyCmp, keys[i], keys[j])
+			}
+		}
+	}
+
+	sorted := append(List{}, list...)
+	sort.Stable(sorted)
+	sort.Strings(keys)
+	for i, key := range keys {
+		v, err := ParseSortable(key)
+		if err != nil {
+			t.Errorf("decoding %s failed with error %s", key, err)
+		} else if v.Compare(sorted[i]) != 0 {
+			t.Errorf("sort failed at position %d (expected %s, actual %s)", i, sorted[i], v)
+		}
+	}
+}
+
+func TestParseSortable(t *testing.T) {
+	testCases := []string{"", "A1A2", "A1A2A3", "A1A2A3x", "B1A2A3.", "B01A2A3.", "A1A2A3-", "A1A2A3-~,", "A1A2A3-~rc", "A1A2A3.+", "A1A2A3-A1"}
+
+	for i, testCase := range testCases {
+		v, err := ParseSortable(testCase)
+		if err == nil {
+			t.Errorf("test %d failed (expected error %s, actual %s)", i, ErrInvalidSortable, v)
+		} else if !errors.Is(err, ErrInvalidSortable) {
+			t.Errorf("test %d failed (expected error %s, actual error %s)", i, ErrInvalidSortable, err)
+		} else {
+			t.Logf("test %d passed with error %s", i, err)
+		}
+	}
+}
+
+func compareStrings(a, b string) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+// valueDB is a database connection whose queries return a single row with a single value, so that versions can be scanned through database/sql.
+type valueDB struct {
+	value driver.Value
+	done  bool
+}
+
+func (db *valueDB) Connect(context.Context) (driver.Conn, error) { return db, nil }
+func (db *valueDB) Driver() driver.Driver                        { return nil }
+func (db *valueDB) Prepare(string) (driver.Stmt, error)          { return db, nil }
+func (db *valueDB) Begin() (driver.Tx, error)                    { return nil, errors.ErrUnsupported }
+func (db *valueDB) Close() error                                 { return nil }
+func (db *valueDB) NumInput() int                                { return 0 }
+func (db *valueDB) Exec([]driver.Value) (driver.Result, error)   { return nil, errors.ErrUnsupported }
+func (db *valueDB) Columns() []string                            { return []string{"version"} }
+
+func (db *valueDB) Query([]driver.Value) (driver.Rows, error) {
+	db.done = false
+	return db, nil
+}
+
+func (db *valueDB) Next(dest []driver.Value) error {
+	if db.done {
+		return io.EOF
+	}
+	db.done = true
+	dest[0] = db.value
+	return nil
+}
@@ -2,6 +2,7 @@ package version
 
 import (
 	"fmt"
+	"strings"
 )
 
 // Version is a structured representation of a version number.
@@ -11,13 +12,27 @@ type Version struct {
 	Patch     int    // Patch version number.
 	Extension string // Version extension, such as pre-release number or build metdata.
 
+	Segments []Number // Numeric segments after the patch number, such as [4] in 1.2.3.4.
+
+	PreRelease []Identifier // Pre-release identifiers, such as [rc 1] in 1.2.3-rc.1.
+	Build      []Identifier // Build metadata identifiers, such as [build 5] in 1.2.3+build.5.
+
+	// Exact major, minor and patch numbers, for any that are too large for an int.
+	// Where set, these take precedence over Major, Minor and Patch, which are set to math.MaxInt.
+	Large [3]Number
+
 	Text string // Original version string, if this version was created via the Parse function.
 }
 
 // Compare this version (a) with another version (b).
 // This function returns -1 if a is less than b, 1 if a is greater than b, or 0 if a is equal to b.
 //
-// Extensions such as pre-release version or build metadata are ignored when comparing versions.
+// Precedence is determined according to Semantic Versioning 2.0.0.
+// A pre-release version has lower precedence than the associated normal version, and build metadata is ignored.
+//
+// Any Segments after the patch number are compared in order, with missing segments treated as zero, so 1.2.3 is equal to 1.2.3.0 and less than 1.2.3.4.
+//
+// See https://semver.org/#spec-item-11
 func (a *Version) Compare(b *Version) int {
 	if a == nil && b != nil {
 		return -1
@@ -27,10 +42,19 @@ func (a *Version) Compare(b *Version) int {
 		return 0
 	}
 
+	if a.Large != [3]Number{} || b.Large != [3]Number{} {
+		for section := sectionMajor; section <= sectionPatch; section++ {
+			if cmp := a.number(section).Compare(b.number(section)); cmp != 0 {
+				return cmp
+			}
+		}
+		return a.compareSegments(b)
+	}
+
 	if a.Major == b.Major {
 		if a.Minor == b.Minor {
 			if a.Patch == b.Patch {
-				return 0
+				return a.compareSegments(b)
 			} else if a.Patch > b.Patch {
 				return 1
 			}
@@ -46,54 +70,70 @@ func (a *Version) Compare(b *Version) int {
 
 // Equal checks for equality between two versions.
 //
-// Extensions such as pre-release version or build metadata are ignored when comparing versions.
+// Build metadata is ignored when comparing versions.
 func (a *Version) Equal(b *Version) bool {
 	return a.Compare(b) == 0
 }
 
 // Match tests the version against a constraint.
-// Gt and Lt take precedence over Gte and Lte.
-func (v *Version) Match(c *Constraint) bool {
+// If the constraint is nil, any version matches.
+func (v *Version) Match(m Matcher) bool {
 	if v == nil {
 		return false
 	}
 
-	if c == nil {
+	if m == nil {
 		return true
 	}
 
-	if c.Gt != nil {
-		if v.Compare(c.Gt) <= 0 {
-			return false
-		}
-	} else if c.Gte != nil {
-		if v.Compare(c.Gte) < 0 {
-			return false
-		}
-	}
-
-	if c.Lt != nil {
-		if v.Compare(c.Lt) >= 0 {
-			return false
-		}
-	} else if c.Lte != nil {
-		if v.Compare(c.Lte) > 0 {
-			return false
-		}
-	}
-
-	return true
+	return m.Match(v)
 }
 
 // Less performs a simple comparison of this version (a) with another version (b).
 // This function returns true if a is less than b, or false otherwise.
 //
-// Extensions such as pre-release version or build metadata are ignored when comparing versions.
+// Build metadata is ignored when comparing versions.
 func (a *Version) Less(b *Version) bool {
 	return a.Compare(b) < 0
 }
 
+// compareSegments compares the segments after the patch number of this version (a) with another version (b), followed by their pre-release identifiers.
+func (a *Version) compareSegments(b *Version) int {
+	for i := 0; i < len(a.Segments) || i < len(b.Segments); i++ {
+		var as, bs Number
+		if i < len(a.Segments) {
+			as = a.Segments[i]
+		}
+		if i < len(b.Segments) {
+			bs = b.Segments[i]
+		}
+		if cmp := as.Compare(bs); cmp != 0 {
+			return cmp
+		}
+	}
+
+	return compareIdentifiers(a.preRelease(), b.preRelease())
+}
+
+// identifiers returns the pre-release and build metadata identifiers of the version.
+// If neither PreRelease nor Build is set, they are taken from Extension instead.
+func (v *Version) identifiers() (preRelease, build []Identifier) {
+	if len(v.PreRelease) > 0 || len(v.Build) > 0 {
+		return v.PreRelease, v.Build
+	}
+	return splitExtension(v.Extension)
+}
+
+// preRelease returns the pre-release identifiers of the version, as described by identifiers.
+func (v *Version) preRelease() []Identifier {
+	preRelease, _ := v.identifiers()
+	return preRelease
+}
+
 // SemanticString returns a version string conforming to the standard described in Semantic Versioning 2.0.0.
+// If PreRelease or Build are set, they are used in place of Extension.
+//
+// Any Segments after the patch number are also included, such as 1.2.3.4, although Semantic Versioning does not allow them.
 //
 // See https://semver.org/#is-v123-a-semantic-version
 func (v *Version) SemanticString() string {
@@ -101,7 +141,19 @@ func (v *Version) SemanticString() string {
 		return ""
 	}
 
-	return fmt.Sprintf("%d.%d.%d%s", v.Major, v.Minor, v.Patch, v.Extension)
+	ext := v.Extension
+	if len(v.PreRelease) > 0 || len(v.Build) > 0 {
+		ext = formatExtension(v.PreRelease, v.Build)
+	}
+
+	if v.Large != [3]Number{} || len(v.Segments) > 0 {
+		strs := []string{}
+		for _, n := range v.Numbers() {
+			strs = append(strs, n.String())
+		}
+		return strings.Join(strs, ".") + ext
+	}
+	return fmt.Sprintf("%d.%d.%d%s", v.Major, v.Minor, v.Patch, ext)
 }
 
 func (v *Version) String() string {
@@ -1,6 +1,7 @@
 package version
 
 import (
+	"math"
 	"testing"
 )
 
@@ -34,6 +35,35 @@ func TestVersion_String(t *testing.T) {
 	}
 }
 
+func TestVersion_SemanticString(t *testing.T) {
+	type TestCase struct {
+		Expected string
+		Input    Version
+	}
+
+	testCases := []TestCase{
+		{Expected: "1.2.3", Input: Version{Major: 1, Minor: 2, Patch: 3, Text: "v1.2.3"}},
+		{Expected: "1.2.0a", Input: Version{Major: 1, Minor: 2, Extension: "a"}},
+		{Expected: "1.2.3-rc.1", Input: Version{Major: 1, Minor: 2, Patch: 3, PreRelease: []Identifier{"rc", "1"}}},
+		{Expected: "1.2.3+build.5", Input: Version{Major: 1, Minor: 2, Patch: 3, Build: []Identifier{"build", "5"}}},
+		{Expected: "1.2.3-rc.1+build.5", Input: Version{Major: 1, Minor: 2, Patch: 3, Extension: "-rc.0", PreRelease: []Identifier{"rc", "1"}, Build: []Identifier{"build", "5"}}},
+		{Expected: "1.2.3-rc.1+build.5", Input: *MustParse("v1.2.3-rc.1+build.5")},
+		{Expected: "1.2.3.4.5", Input: *MustParse("v1.2.3.04.5")},
+		{Expected: "1.2.99999999999999999999", Input: *MustParse("v1.2.099999999999999999999")},
+		{Expected: "1.99999999999999999999.3-rc.1", Input: Version{Major: 1, Minor: math.MaxInt, Patch: 3, Large: [3]Number{1: "99999999999999999999"}, PreRelease: []Identifier{"rc", "1"}}},
+	}
+
+	for i, testCase := range testCases {
+		actual := testCase.Input.SemanticString()
+
+		if actual != testCase.Expected {
+			t.Errorf("test %d failed (expected %s, actual %s)", i, testCase.Expected, actual)
+		} else {
+			t.Logf("test %d passed with %s\n", i, actual)
+		}
+	}
+}
+
 func TestVersion_Compare(t *testing.T) {
 	type TestCase struct {
 		A        *Version
@@ -55,6 +85,28 @@ func TestVersion_Compare(t *testing.T) {
 		{A: MustParse("1.20.0"), B: MustParse("1.2.0"), Expected: 1},
 		{A: MustParse("1.20.0"), B: MustParse("1.2.20"), Expected: 1},
 		{A: MustParse("1.20.0"), B: MustParse("1.20.1"), Expected: -1},
+		{A: MustParse("1.0.0-alpha"), B: MustParse("1.0.0"), Expected: -1},
+		{A: MustParse("1.0.0"), B: MustParse("1.0.0-rc.1"), Expected: 1},
+		{A: MustParse("1.0.0-alpha"), B: MustParse("1.0.0-alpha.1"), Expected: -1},
+		{A: MustParse("1.0.0-alpha.1"), B: MustParse("1.0.0-alpha.beta"), Expected: -1},
+		{A: MustParse("1.0.0-beta.11"), B: MustParse("1.0.0-beta.2"), Expected: 1},
+		{A: MustParse("1.0.0-rc.1"), B: MustParse("1.0.0-rc.01"), Expected: 0},
+		{A: MustParse("1.0.0-rc.1"), B: MustParse("0.9.0"), Expected: 1},
+		{A: MustParse("1.0.0+build.1"), B: MustParse("1.0.0+build.2"), Expected: 0},
+		{A: MustParse("1.0.0-rc.1+build.1"), B: MustParse("1.0.0-rc.1"), Expected: 0},
+		{A: MustParse("1.0.0a"), B: MustParse("1.0.0b"), Expected: -1},
+		{A: &Version{Major: 1, PreRelease: []Identifier{"rc", "2"}}, B: &Version{Major: 1, Extension: "-rc.10"}, Expected: -1},
+		{A: MustParse("1.2.99999999999999999999"), B: MustParse("1.2.100000000000000000000"), Expected: -1},
+		{A: MustParse("1.2.99999999999999999999"), B: MustParse("1.2.0099999999999999999999"), Expected: 0},
+		{A: MustParse("1.2.99999999999999999999"), B: MustParse("1.3.0"), Expected: -1},
+		{A: MustParse("1.2.99999999999999999999"), B: MustParse("1.2.9223372036854775807"), Expected: 1},
+		{A: MustParse("99999999999999999999.0.0-rc.1"), B: MustParse("99999999999999999999.0.0"), Expected: -1},
+		{A: MustParse("1.2.3.4"), B: MustParse("1.2.3.9"), Expected: -1},
+		{A: MustParse("1.2.3.10"), B: MustParse("1.2.3.9"), Expected: 1},
+		{A: MustParse("1.2.3"), B: MustParse("1.2.3.0.0"), Expected: 0},
+		{A: MustParse("1.2.3"), B: MustParse("1.2.3.1"), Expected: -1},
+		{A: MustParse("1.2.3.1-rc.1"), B: MustParse("1.2.3.1"), Expected: -1},
+		{A: MustParse("1.2.4"), B: MustParse("1.2.3.99999999999999999999"), Expected: 1},
 		{A: MustParse("1.0.0"), Expected: 1},
 		{B: MustParse("1.0.0"), Expected: -1},
 		{Expected: 0},
@@ -91,6 +143,9 @@ func TestVersion_Less(t *testing.T) {
 		{A: MustParse("1.20.0"), B: MustParse("1.2.0"), Expected: false},
 		{A: MustParse("1.20.0"), B: MustParse("1.2.20"), Expected: false},
 		{A: MustParse("1.20.0"), B: MustParse("1.20.1"), Expected: true},
+		{A: MustParse("1.0.0-alpha"), B: MustParse("1.0.0"), Expected: true},
+		{A: MustParse("1.0.0"), B: MustParse("1.0.0-alpha"), Expected: false},
+		{A: MustParse("1.0.0+build.1"), B: MustParse("1.0.0"), Expected: false},
 	}
 
 	for i, testCase := range testCases {
@@ -172,6 +227,10 @@ func TestVersion_Match(t *testing.T) {
 		{V: MustParse("1.0.0"), C: &Constraint{Lt: MustParse("1.0.0"), Lte: MustParse("1.0.0")}, Expected: false},
 		{V: MustParse("1.0.0"), C: &Constraint{Lt: MustParse("0.1.0"), Lte: MustParse("1.1.0")}, Expected: false},
 		{V: MustParse("1.0.0"), C: &Constraint{Lt: MustParse("1.1.0"), Lte: MustParse("0.1.0")}, Expected: true},
+
+		{V: MustParse("1.0.0-rc.1"), C: &Constraint{Lt: MustParse("1.0.0")}, Expected: true},
+		{V: MustParse("1.0.0-rc.1"), C: &Constraint{Gte: MustParse("1.0.0")}, Expected: false},
+		{V: MustParse("1.0.0-rc.2"), C: &Constraint{Gt: MustParse("1.0.0-rc.1")}, Expected: true},
 	}
 
 	for i, testCase := range testCases {